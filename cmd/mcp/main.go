@@ -15,24 +15,37 @@ import (
 	"github.com/algolia/algoliasearch-client-go/v3/algolia/search"
 	"github.com/algolia/mcp/pkg/abtesting"
 	"github.com/algolia/mcp/pkg/analytics"
+	"github.com/algolia/mcp/pkg/apikeys"
 	"github.com/algolia/mcp/pkg/collections"
+	"github.com/algolia/mcp/pkg/dictionaries"
+	"github.com/algolia/mcp/pkg/mcm"
 	"github.com/algolia/mcp/pkg/monitoring"
 	"github.com/algolia/mcp/pkg/querysuggestions"
 	"github.com/algolia/mcp/pkg/recommend"
 	searchpkg "github.com/algolia/mcp/pkg/search"
+	"github.com/algolia/mcp/pkg/search/backup"
+	"github.com/algolia/mcp/pkg/search/indexcache"
+	"github.com/algolia/mcp/pkg/search/recordschema"
+	"github.com/algolia/mcp/pkg/search/settingshistory"
+	"github.com/algolia/mcp/pkg/security"
 	"github.com/algolia/mcp/pkg/usage"
 
 	"github.com/mark3labs/mcp-go/server"
 )
 
 func main() {
+	// Subcommands run once and exit instead of starting the server.
+	if len(os.Args) > 1 && os.Args[1] == "relevance-test" {
+		os.Exit(runRelevanceTest(os.Args[2:]))
+	}
+
 	// Create a new MCP server with name and version
 	mcps := server.NewMCPServer("Algolia MCP", "0.0.2")
 
 	// Parse MCP_ENABLED_TOOLS environment variable to determine which toolsets to enable
 	enabledToolsEnv := os.Getenv("MCP_ENABLED_TOOLS")
 	enabled := make(map[string]bool)
-	allTools := []string{"abtesting", "analytics", "collections", "monitoring", "querysuggestions", "recommend", "search", "search_read", "search_write", "usage"}
+	allTools := []string{"abtesting", "analytics", "apikeys", "collections", "dictionaries", "mcm", "mcm_read", "mcm_write", "monitoring", "querysuggestions", "recommend", "search", "search_read", "search_write", "security", "usage"}
 
 	// If MCP_ENABLED_TOOLS is set, enable only the specified toolsets
 	// Otherwise, enable all toolsets
@@ -52,10 +65,6 @@ func main() {
 		}
 	}
 
-	// Initialize Algolia client
-	var searchClient *search.Client
-	var searchIndex *search.Index
-
 	// Get Algolia credentials from environment variables
 	appID := os.Getenv("ALGOLIA_APP_ID")
 	apiKey := os.Getenv("ALGOLIA_API_KEY")
@@ -65,11 +74,50 @@ func main() {
 	fmt.Printf("apiKey: %v\n", apiKey)
 	fmt.Printf("indexName: %v\n", indexName)
 
-	searchClient = search.NewClient(appID, apiKey)
-	searchIndex = searchClient.InitIndex(indexName)
+	// Initialize the Algolia client and the cache of indices the search tools act on.
+	// ALGOLIA_INDEX_ALLOWLIST and ALGOLIA_INDEX_DENYLIST optionally restrict the
+	// indices that can be targeted, as comma-separated patterns (e.g. "products_*").
+	searchIndices := indexcache.New(
+		appID,
+		apiKey,
+		indexName,
+		indexcache.ParsePatterns(os.Getenv("ALGOLIA_INDEX_ALLOWLIST")),
+		indexcache.ParsePatterns(os.Getenv("ALGOLIA_INDEX_DENYLIST")),
+	)
+
+	// ALGOLIA_PROFILES_FILE optionally points to a JSON file of named credentials for other
+	// applications, used by the tools that work across applications.
+	if profilesFile := os.Getenv("ALGOLIA_PROFILES_FILE"); profilesFile != "" {
+		profiles, err := indexcache.LoadProfiles(profilesFile)
+		if err != nil {
+			log.Fatalf("Invalid ALGOLIA_PROFILES_FILE: %v", err)
+		}
+		searchIndices.SetProfiles(profiles)
+	}
+
+	// Settings snapshots are stored locally before every settings change so they can be rolled back.
+	settingsHistoryDir := os.Getenv("ALGOLIA_SETTINGS_HISTORY_DIR")
+	if settingsHistoryDir == "" {
+		settingsHistoryDir = settingshistory.DefaultDir()
+	}
+	settingsHistory := settingshistory.New(settingsHistoryDir, appID)
+
+	// Index backups are written under ALGOLIA_BACKUP_DIR unless a tool call names another directory.
+	backupDir := os.Getenv("ALGOLIA_BACKUP_DIR")
+	if backupDir == "" {
+		backupDir = backup.DefaultDir()
+	}
 
-	fmt.Println(searchClient)
-	fmt.Println(searchIndex)
+	// ALGOLIA_SCHEMAS_FILE optionally maps indices to JSON Schema files that the write tools
+	// validate records against. Record sizes are checked either way.
+	var recordValidator *recordschema.Validator
+	if schemasFile := os.Getenv("ALGOLIA_SCHEMAS_FILE"); schemasFile != "" {
+		v, err := recordschema.Load(schemasFile)
+		if err != nil {
+			log.Fatalf("Invalid ALGOLIA_SCHEMAS_FILE: %v", err)
+		}
+		recordValidator = v
+	}
 
 	// Register tools from enabled packages.
 	if enabled["abtesting"] {
@@ -78,9 +126,41 @@ func main() {
 	if enabled["analytics"] {
 		analytics.RegisterTools(mcps)
 	}
+	if enabled["apikeys"] {
+		apikeys.RegisterSecuredKeys(mcps, apiKey)
+
+		// API key management requires the admin API key.
+		if adminKey := os.Getenv("ALGOLIA_WRITE_API_KEY"); adminKey != "" {
+			apikeys.RegisterAll(mcps, search.NewClient(appID, adminKey))
+		} else {
+			log.Println("Warning: ALGOLIA_WRITE_API_KEY is not set, API key management tools are disabled")
+		}
+	}
 	if enabled["collections"] {
 		collections.RegisterTools(mcps)
 	}
+	if enabled["dictionaries"] {
+		dictionaries.RegisterAll(mcps, searchIndices.Client())
+	}
+	if enabled["mcm"] || enabled["mcm_read"] || enabled["mcm_write"] {
+		// Multi-cluster management requires the admin API key.
+		if adminKey := os.Getenv("ALGOLIA_WRITE_API_KEY"); adminKey != "" {
+			mcmClient := search.NewClient(appID, adminKey)
+			if enabled["mcm"] {
+				mcm.RegisterAll(mcps, mcmClient)
+			} else {
+				// Only register specific MCM tools if "mcm" is not enabled
+				if enabled["mcm_read"] {
+					mcm.RegisterReadAll(mcps, mcmClient)
+				}
+				if enabled["mcm_write"] {
+					mcm.RegisterWriteAll(mcps, mcmClient)
+				}
+			}
+		} else {
+			log.Println("Warning: ALGOLIA_WRITE_API_KEY is not set, mcm tools are disabled")
+		}
+	}
 	if enabled["monitoring"] {
 		monitoring.RegisterTools(mcps)
 	}
@@ -91,14 +171,22 @@ func main() {
 		recommend.RegisterAll(mcps)
 	}
 	if enabled["search"] {
-		searchpkg.RegisterAll(mcps)
+		searchpkg.RegisterAll(mcps, searchIndices, settingsHistory, backupDir, recordValidator)
 	} else {
 		// Only register specific search tools if "search" is not enabled
 		if enabled["search_read"] {
-			searchpkg.RegisterReadAll(mcps, searchClient, searchIndex)
+			searchpkg.RegisterReadAll(mcps, searchIndices, settingsHistory)
 		}
 		if enabled["search_write"] {
-			searchpkg.RegisterWriteAll(mcps, searchClient, searchIndex)
+			searchpkg.RegisterWriteAll(mcps, searchIndices, settingsHistory, backupDir, recordValidator)
+		}
+	}
+	if enabled["security"] {
+		// The allowed sources can only be managed with the admin API key.
+		if adminKey := os.Getenv("ALGOLIA_WRITE_API_KEY"); adminKey != "" {
+			security.RegisterAll(mcps, search.NewClient(appID, adminKey))
+		} else {
+			log.Println("Warning: ALGOLIA_WRITE_API_KEY is not set, security tools are disabled")
 		}
 	}
 	if enabled["usage"] {
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/algolia/mcp/pkg/search/indexcache"
+	"github.com/algolia/mcp/pkg/search/relevance"
+)
+
+// runRelevanceTest implements the relevance-test subcommand, which runs a
+// relevance suite outside of an MCP session (e.g. in CI). It returns the exit
+// code: 0 when every query passes, 1 when some fail and 2 on errors.
+func runRelevanceTest(args []string) int {
+	fs := flag.NewFlagSet("relevance-test", flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: mcp relevance-test [flags] <suite.json>")
+		fs.PrintDefaults()
+	}
+	indexName := fs.String("index", "", "run every query against this index, overriding the suite")
+	k := fs.Int("k", 0, "number of hits to check and score, overriding the suite")
+	asJSON := fs.Bool("json", false, "print the report as JSON")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return 2
+	}
+
+	suite, err := relevance.LoadSuite(fs.Arg(0))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 2
+	}
+	if *indexName != "" {
+		suite.IndexName = *indexName
+		for i := range suite.Queries {
+			suite.Queries[i].IndexName = ""
+		}
+	}
+	if *k > 0 {
+		suite.K = *k
+	}
+
+	cache := indexcache.New(
+		os.Getenv("ALGOLIA_APP_ID"),
+		os.Getenv("ALGOLIA_API_KEY"),
+		os.Getenv("ALGOLIA_INDEX_NAME"),
+		indexcache.ParsePatterns(os.Getenv("ALGOLIA_INDEX_ALLOWLIST")),
+		indexcache.ParsePatterns(os.Getenv("ALGOLIA_INDEX_DENYLIST")),
+	)
+	report, err := relevance.Run(cache, suite)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 2
+	}
+
+	if *asJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(report); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 2
+		}
+	} else {
+		fmt.Print(relevance.Format(report))
+	}
+
+	if report.NbFailed > 0 {
+		return 1
+	}
+	return 0
+}
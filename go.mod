@@ -5,6 +5,8 @@ go 1.24.1
 require (
 	github.com/algolia/algoliasearch-client-go/v3 v3.31.4
 	github.com/mark3labs/mcp-go v0.24.1
+	github.com/santhosh-tekuri/jsonschema/v6 v6.0.3
+	golang.org/x/text v0.14.0
 )
 
 require (
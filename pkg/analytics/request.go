@@ -0,0 +1,43 @@
+package analytics
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// baseURL is the address of the Analytics API.
+const baseURL = "https://analytics.algolia.com"
+
+// Get sends a GET request to an Analytics API endpoint, e.g. /2/searches, and
+// decodes the response into v. It lets other toolsets read analytics.
+func Get(appID, apiKey, path string, params url.Values, v any) error {
+	httpReq, err := http.NewRequest(http.MethodGet, baseURL+path, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	httpReq.Header.Set("x-algolia-application-id", appID)
+	httpReq.Header.Set("x-algolia-api-key", apiKey)
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.URL.RawQuery = params.Encode()
+
+	resp, err := http.DefaultClient.Do(httpReq)
+	if err != nil {
+		return fmt.Errorf("failed to execute request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		var errResp map[string]any
+		if err := json.NewDecoder(resp.Body).Decode(&errResp); err != nil {
+			return fmt.Errorf("Algolia API error (status %d)", resp.StatusCode)
+		}
+		return fmt.Errorf("Algolia API error: %v", errResp)
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return fmt.Errorf("failed to parse response: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,151 @@
+package apikeys
+
+import (
+	"strings"
+	"time"
+
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/search"
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/transport"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// RegisterAll registers all API key tools with the MCP server. The client must
+// be created with an admin API key.
+func RegisterAll(mcps *server.MCPServer, client *search.Client) {
+	// Register all API key tools.
+	RegisterListKeys(mcps, client)
+	RegisterGetKey(mcps, client)
+	RegisterCreateKey(mcps, client)
+	RegisterUpdateKey(mcps, client)
+	RegisterDeleteKey(mcps, client)
+	RegisterRestoreKey(mcps, client)
+}
+
+// RegisterSecuredKeys registers the tools that generate and inspect secured
+// API keys locally. They don't need an admin API key; parentKey is the search
+// API key used when a tool call doesn't provide one.
+func RegisterSecuredKeys(mcps *server.MCPServer, parentKey string) {
+	RegisterGenerateSecuredKey(mcps, parentKey)
+	RegisterInspectSecuredKey(mcps, parentKey)
+}
+
+// keyView is the representation of an API key returned by the tools.
+type keyView struct {
+	Value                  string    `json:"value"`
+	Description            string    `json:"description,omitempty"`
+	ACL                    []string  `json:"acl"`
+	Indexes                []string  `json:"indexes,omitempty"`
+	Referers               []string  `json:"referers,omitempty"`
+	MaxQueriesPerIPPerHour int       `json:"maxQueriesPerIPPerHour,omitempty"`
+	MaxHitsPerQuery        int       `json:"maxHitsPerQuery,omitempty"`
+	QueryParameters        string    `json:"queryParameters,omitempty"`
+	Validity               int64     `json:"validity,omitempty"`
+	CreatedAt              time.Time `json:"createdAt"`
+}
+
+func newKeyView(k search.Key, reveal bool) keyView {
+	value := k.Value
+	if !reveal {
+		value = maskKey(value)
+	}
+	return keyView{
+		Value:                  value,
+		Description:            k.Description,
+		ACL:                    k.ACL,
+		Indexes:                k.Indexes,
+		Referers:               k.Referers,
+		MaxQueriesPerIPPerHour: k.MaxQueriesPerIPPerHour,
+		MaxHitsPerQuery:        k.MaxHitsPerQuery,
+		QueryParameters:        transport.URLEncode(k.QueryParameters),
+		Validity:               int64(k.Validity.Seconds()),
+		CreatedAt:              k.CreatedAt,
+	}
+}
+
+// maskKey hides all but the first and last four characters of a key.
+func maskKey(key string) string {
+	if len(key) <= 8 {
+		return strings.Repeat("*", len(key))
+	}
+	return key[:4] + strings.Repeat("*", len(key)-8) + key[len(key)-4:]
+}
+
+// revealOption is the option shared by the tools that return key values.
+func revealOption() mcp.ToolOption {
+	return mcp.WithBoolean(
+		"reveal",
+		mcp.Description("Whether to return full key values instead of masked ones (defaults to false)"),
+	)
+}
+
+// splitList splits a comma-separated list, ignoring empty items.
+func splitList(s string) []string {
+	items := []string{}
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// keyOptions are the tool options describing the permissions of a key,
+// shared by the create and update tools.
+func keyOptions() []mcp.ToolOption {
+	return []mcp.ToolOption{
+		mcp.WithString(
+			"acl",
+			mcp.Description("Comma-separated list of permissions (e.g., search,browse,addObject,deleteObject,listIndexes,settings,editSettings,analytics,recommendation,usage,logs)"),
+		),
+		mcp.WithString(
+			"description",
+			mcp.Description("Description of the key"),
+		),
+		mcp.WithString(
+			"indexes",
+			mcp.Description("Comma-separated list of index names or patterns (e.g., dev_*) the key can access. Empty means all indices"),
+		),
+		mcp.WithString(
+			"referers",
+			mcp.Description("Comma-separated list of allowed referers (e.g., *.example.com/*)"),
+		),
+		mcp.WithNumber(
+			"validity",
+			mcp.Description("Duration in seconds after which the key expires. 0 means it never expires"),
+		),
+		mcp.WithNumber(
+			"maxQueriesPerIPPerHour",
+			mcp.Description("Maximum number of API requests per IP address per hour. 0 means no limit"),
+		),
+		mcp.WithNumber(
+			"maxHitsPerQuery",
+			mcp.Description("Maximum number of hits per query. 0 means no limit"),
+		),
+	}
+}
+
+// applyKeyArguments sets the fields of key given in the tool arguments.
+func applyKeyArguments(key *search.Key, args map[string]any) {
+	if acl, ok := args["acl"].(string); ok {
+		key.ACL = splitList(acl)
+	}
+	if description, ok := args["description"].(string); ok {
+		key.Description = description
+	}
+	if indexes, ok := args["indexes"].(string); ok {
+		key.Indexes = splitList(indexes)
+	}
+	if referers, ok := args["referers"].(string); ok {
+		key.Referers = splitList(referers)
+	}
+	if validity, ok := args["validity"].(float64); ok {
+		key.Validity = time.Duration(validity) * time.Second
+	}
+	if maxQueries, ok := args["maxQueriesPerIPPerHour"].(float64); ok {
+		key.MaxQueriesPerIPPerHour = int(maxQueries)
+	}
+	if maxHits, ok := args["maxHitsPerQuery"].(float64); ok {
+		key.MaxHitsPerQuery = int(maxHits)
+	}
+}
@@ -0,0 +1,38 @@
+package apikeys
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/search"
+	"github.com/algolia/mcp/pkg/mcputil"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// RegisterCreateKey registers the create_key tool with the MCP server.
+func RegisterCreateKey(mcps *server.MCPServer, client *search.Client) {
+	opts := append([]mcp.ToolOption{
+		mcp.WithDescription("Create a new API key with the given permissions. The new key value is masked unless reveal is true"),
+	}, keyOptions()...)
+	opts = append(opts, revealOption())
+	createKeyTool := mcp.NewTool("apikeys_create_key", opts...)
+
+	mcps.AddTool(createKeyTool, func(_ context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		var key search.Key
+		applyKeyArguments(&key, req.Params.Arguments)
+		if len(key.ACL) == 0 {
+			return nil, fmt.Errorf("acl parameter is required")
+		}
+		reveal, _ := req.Params.Arguments["reveal"].(bool)
+
+		res, err := client.AddAPIKey(key)
+		if err != nil {
+			return nil, fmt.Errorf("could not create API key: %w", err)
+		}
+
+		key.Value = res.Key
+		key.CreatedAt = res.CreatedAt
+		return mcputil.JSONToolResult("API Key", newKeyView(key, reveal))
+	})
+}
@@ -0,0 +1,41 @@
+package apikeys
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/search"
+	"github.com/algolia/mcp/pkg/mcputil"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// RegisterDeleteKey registers the delete_key tool with the MCP server.
+func RegisterDeleteKey(mcps *server.MCPServer, client *search.Client) {
+	deleteKeyTool := mcp.NewTool(
+		"apikeys_delete_key",
+		mcp.WithDescription("Delete an API key. Deleted keys can be restored for up to 72 hours"),
+		mcp.WithString(
+			"key",
+			mcp.Description("The API key to delete"),
+			mcp.Required(),
+		),
+	)
+
+	mcps.AddTool(deleteKeyTool, func(_ context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		keyID, _ := req.Params.Arguments["key"].(string)
+		if keyID == "" {
+			return nil, fmt.Errorf("key parameter is required")
+		}
+
+		res, err := client.DeleteAPIKey(keyID)
+		if err != nil {
+			return nil, fmt.Errorf("could not delete API key: %w", err)
+		}
+
+		return mcputil.JSONToolResult("Delete Result", map[string]any{
+			"key":       maskKey(keyID),
+			"deletedAt": res.DeletedAt,
+		})
+	})
+}
@@ -0,0 +1,106 @@
+package apikeys
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/opt"
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/search"
+	"github.com/algolia/mcp/pkg/mcputil"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// RegisterGenerateSecuredKey registers the generate_secured_api_key tool with the MCP server.
+func RegisterGenerateSecuredKey(mcps *server.MCPServer, defaultParentKey string) {
+	generateSecuredKeyTool := mcp.NewTool(
+		"apikeys_generate_secured_api_key",
+		mcp.WithDescription("Generate a secured API key locally from a parent search key, with embedded restrictions. No API call is made"),
+		mcp.WithString(
+			"parentKey",
+			mcp.Description("The search API key to derive the secured key from (defaults to ALGOLIA_API_KEY)"),
+		),
+		mcp.WithString(
+			"filters",
+			mcp.Description("Filters applied to every search made with the key (e.g., 'tenant:acme AND visible:true')"),
+		),
+		mcp.WithString(
+			"restrictIndices",
+			mcp.Description("Comma-separated list of index names or patterns the key can search"),
+		),
+		mcp.WithNumber(
+			"validUntil",
+			mcp.Description("Unix timestamp after which the key expires"),
+		),
+		mcp.WithNumber(
+			"validFor",
+			mcp.Description("Number of seconds from now after which the key expires, as an alternative to validUntil"),
+		),
+		mcp.WithString(
+			"userToken",
+			mcp.Description("User identifier used for rate limiting and analytics"),
+		),
+		mcp.WithString(
+			"restrictSources",
+			mcp.Description("IPv4 network allowed to use the key (e.g., 192.168.1.0/24)"),
+		),
+		mcp.WithString(
+			"referers",
+			mcp.Description("Comma-separated list of allowed referers"),
+		),
+	)
+
+	mcps.AddTool(generateSecuredKeyTool, func(_ context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		parentKey, _ := req.Params.Arguments["parentKey"].(string)
+		if parentKey == "" {
+			parentKey = defaultParentKey
+		}
+		if parentKey == "" {
+			return nil, fmt.Errorf("parentKey parameter is required when ALGOLIA_API_KEY is not set")
+		}
+
+		opts := []any{}
+		if filters, ok := req.Params.Arguments["filters"].(string); ok && filters != "" {
+			opts = append(opts, opt.Filters(filters))
+		}
+		if indices, ok := req.Params.Arguments["restrictIndices"].(string); ok && indices != "" {
+			opts = append(opts, opt.RestrictIndices(splitList(indices)...))
+		}
+		validUntil, hasValidUntil := req.Params.Arguments["validUntil"].(float64)
+		validFor, hasValidFor := req.Params.Arguments["validFor"].(float64)
+		if hasValidUntil && hasValidFor {
+			return nil, fmt.Errorf("validUntil and validFor are mutually exclusive")
+		}
+		if hasValidUntil {
+			opts = append(opts, opt.ValidUntil(time.Unix(int64(validUntil), 0)))
+		}
+		if hasValidFor {
+			opts = append(opts, opt.ValidUntil(time.Now().Add(time.Duration(validFor)*time.Second)))
+		}
+		if userToken, ok := req.Params.Arguments["userToken"].(string); ok && userToken != "" {
+			opts = append(opts, opt.UserToken(userToken))
+		}
+		if sources, ok := req.Params.Arguments["restrictSources"].(string); ok && sources != "" {
+			opts = append(opts, opt.RestrictSources(sources))
+		}
+		if referers, ok := req.Params.Arguments["referers"].(string); ok && referers != "" {
+			opts = append(opts, opt.Referers(splitList(referers)...))
+		}
+
+		key, err := search.GenerateSecuredAPIKey(parentKey, opts...)
+		if err != nil {
+			return nil, fmt.Errorf("could not generate secured API key: %w", err)
+		}
+
+		info, err := inspectSecuredKey(key, parentKey)
+		if err != nil {
+			return nil, err
+		}
+
+		return mcputil.JSONToolResult("Secured API Key", map[string]any{
+			"key":          key,
+			"restrictions": info,
+		})
+	})
+}
@@ -0,0 +1,40 @@
+package apikeys
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/search"
+	"github.com/algolia/mcp/pkg/mcputil"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// RegisterGetKey registers the get_key tool with the MCP server.
+func RegisterGetKey(mcps *server.MCPServer, client *search.Client) {
+	getKeyTool := mcp.NewTool(
+		"apikeys_get_key",
+		mcp.WithDescription("Get the permissions of an API key. The key value is masked unless reveal is true"),
+		mcp.WithString(
+			"key",
+			mcp.Description("The API key to retrieve"),
+			mcp.Required(),
+		),
+		revealOption(),
+	)
+
+	mcps.AddTool(getKeyTool, func(_ context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		keyID, _ := req.Params.Arguments["key"].(string)
+		if keyID == "" {
+			return nil, fmt.Errorf("key parameter is required")
+		}
+		reveal, _ := req.Params.Arguments["reveal"].(bool)
+
+		key, err := client.GetAPIKey(keyID)
+		if err != nil {
+			return nil, fmt.Errorf("could not get API key: %w", err)
+		}
+
+		return mcputil.JSONToolResult("API Key", newKeyView(key, reveal))
+	})
+}
@@ -0,0 +1,144 @@
+package apikeys
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/algolia/mcp/pkg/mcputil"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// securedKeyInfo describes the restrictions embedded in a secured API key.
+type securedKeyInfo struct {
+	Filters           string            `json:"filters,omitempty"`
+	RestrictIndices   []string          `json:"restrictIndices,omitempty"`
+	RestrictSources   string            `json:"restrictSources,omitempty"`
+	Referers          []string          `json:"referers,omitempty"`
+	UserToken         string            `json:"userToken,omitempty"`
+	ValidUntil        *time.Time        `json:"validUntil,omitempty"`
+	Expired           bool              `json:"expired"`
+	RemainingValidity string            `json:"remainingValidity,omitempty"`
+	OtherParameters   map[string]string `json:"otherParameters,omitempty"`
+	SignatureVerified *bool             `json:"signatureVerified,omitempty"`
+}
+
+// RegisterInspectSecuredKey registers the inspect_secured_api_key tool with the MCP server.
+func RegisterInspectSecuredKey(mcps *server.MCPServer, defaultParentKey string) {
+	inspectSecuredKeyTool := mcp.NewTool(
+		"apikeys_inspect_secured_api_key",
+		mcp.WithDescription("Decode a secured API key locally and report its embedded restrictions and remaining validity"),
+		mcp.WithString(
+			"key",
+			mcp.Description("The secured API key to inspect"),
+			mcp.Required(),
+		),
+		mcp.WithString(
+			"parentKey",
+			mcp.Description("The parent search API key, used to verify the key's signature (defaults to ALGOLIA_API_KEY)"),
+		),
+	)
+
+	mcps.AddTool(inspectSecuredKeyTool, func(_ context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		key, _ := req.Params.Arguments["key"].(string)
+		if key == "" {
+			return nil, fmt.Errorf("key parameter is required")
+		}
+		parentKey, _ := req.Params.Arguments["parentKey"].(string)
+		if parentKey == "" {
+			parentKey = defaultParentKey
+		}
+
+		info, err := inspectSecuredKey(key, parentKey)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		return mcputil.JSONToolResult("Secured API Key", info)
+	})
+}
+
+// inspectSecuredKey decodes a secured API key. A secured key is the base64
+// encoding of the hex HMAC-SHA256 of its query string, keyed by the parent
+// key, followed by the query string itself. The signature is only checked
+// when parentKey is not empty.
+func inspectSecuredKey(key, parentKey string) (securedKeyInfo, error) {
+	var info securedKeyInfo
+
+	decoded, err := base64.StdEncoding.DecodeString(key)
+	if err != nil {
+		return info, fmt.Errorf("not a secured API key: %w", err)
+	}
+	if len(decoded) < sha256.Size*2 {
+		return info, fmt.Errorf("not a secured API key: too short")
+	}
+	checksum, query := string(decoded[:sha256.Size*2]), string(decoded[sha256.Size*2:])
+	if _, err := hex.DecodeString(checksum); err != nil {
+		return info, fmt.Errorf("not a secured API key: invalid checksum")
+	}
+
+	if parentKey != "" {
+		h := hmac.New(sha256.New, []byte(parentKey))
+		h.Write([]byte(query))
+		verified := hmac.Equal([]byte(hex.EncodeToString(h.Sum(nil))), []byte(checksum))
+		info.SignatureVerified = &verified
+	}
+
+	params, err := url.ParseQuery(query)
+	if err != nil {
+		return info, fmt.Errorf("could not parse key restrictions: %w", err)
+	}
+
+	for name, values := range params {
+		value := values[0]
+		switch name {
+		case "filters":
+			info.Filters = value
+		case "restrictIndices":
+			info.RestrictIndices = decodeList(value)
+		case "restrictSources":
+			info.RestrictSources = value
+		case "referers":
+			info.Referers = decodeList(value)
+		case "userToken":
+			info.UserToken = value
+		case "validUntil":
+			ts, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				return info, fmt.Errorf("invalid validUntil %q", value)
+			}
+			validUntil := time.Unix(ts, 0).UTC()
+			info.ValidUntil = &validUntil
+			if remaining := time.Until(validUntil); remaining > 0 {
+				info.RemainingValidity = remaining.Round(time.Second).String()
+			} else {
+				info.Expired = true
+			}
+		default:
+			if info.OtherParameters == nil {
+				info.OtherParameters = make(map[string]string)
+			}
+			info.OtherParameters[name] = value
+		}
+	}
+
+	return info, nil
+}
+
+// decodeList decodes a list parameter, which is either a JSON array or a
+// comma-separated list.
+func decodeList(s string) []string {
+	var items []string
+	if err := json.Unmarshal([]byte(s), &items); err == nil {
+		return items
+	}
+	return splitList(s)
+}
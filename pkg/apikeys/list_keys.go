@@ -0,0 +1,36 @@
+package apikeys
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/search"
+	"github.com/algolia/mcp/pkg/mcputil"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// RegisterListKeys registers the list_keys tool with the MCP server.
+func RegisterListKeys(mcps *server.MCPServer, client *search.Client) {
+	listKeysTool := mcp.NewTool(
+		"apikeys_list_keys",
+		mcp.WithDescription("List the API keys of the application with their permissions. Key values are masked unless reveal is true"),
+		revealOption(),
+	)
+
+	mcps.AddTool(listKeysTool, func(_ context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		reveal, _ := req.Params.Arguments["reveal"].(bool)
+
+		res, err := client.ListAPIKeys()
+		if err != nil {
+			return nil, fmt.Errorf("could not list API keys: %w", err)
+		}
+
+		keys := make([]keyView, len(res.Keys))
+		for i, k := range res.Keys {
+			keys[i] = newKeyView(k, reveal)
+		}
+
+		return mcputil.JSONToolResult("API Keys", keys)
+	})
+}
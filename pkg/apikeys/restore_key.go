@@ -0,0 +1,41 @@
+package apikeys
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/search"
+	"github.com/algolia/mcp/pkg/mcputil"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// RegisterRestoreKey registers the restore_key tool with the MCP server.
+func RegisterRestoreKey(mcps *server.MCPServer, client *search.Client) {
+	restoreKeyTool := mcp.NewTool(
+		"apikeys_restore_key",
+		mcp.WithDescription("Restore an API key deleted less than 72 hours ago"),
+		mcp.WithString(
+			"key",
+			mcp.Description("The API key to restore"),
+			mcp.Required(),
+		),
+	)
+
+	mcps.AddTool(restoreKeyTool, func(_ context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		keyID, _ := req.Params.Arguments["key"].(string)
+		if keyID == "" {
+			return nil, fmt.Errorf("key parameter is required")
+		}
+
+		res, err := client.RestoreAPIKey(keyID)
+		if err != nil {
+			return nil, fmt.Errorf("could not restore API key: %w", err)
+		}
+
+		return mcputil.JSONToolResult("Restore Result", map[string]any{
+			"key":       maskKey(keyID),
+			"createdAt": res.CreatedAt,
+		})
+	})
+}
@@ -0,0 +1,51 @@
+package apikeys
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/search"
+	"github.com/algolia/mcp/pkg/mcputil"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// RegisterUpdateKey registers the update_key tool with the MCP server.
+func RegisterUpdateKey(mcps *server.MCPServer, client *search.Client) {
+	opts := append([]mcp.ToolOption{
+		mcp.WithDescription("Update the permissions of an existing API key. Only the given permissions change, the others are kept"),
+		mcp.WithString(
+			"key",
+			mcp.Description("The API key to update"),
+			mcp.Required(),
+		),
+	}, keyOptions()...)
+	opts = append(opts, revealOption())
+	updateKeyTool := mcp.NewTool("apikeys_update_key", opts...)
+
+	mcps.AddTool(updateKeyTool, func(_ context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		keyID, _ := req.Params.Arguments["key"].(string)
+		if keyID == "" {
+			return nil, fmt.Errorf("key parameter is required")
+		}
+		reveal, _ := req.Params.Arguments["reveal"].(bool)
+
+		// The API replaces the whole key, so start from its current permissions.
+		key, err := client.GetAPIKey(keyID)
+		if err != nil {
+			return nil, fmt.Errorf("could not get API key: %w", err)
+		}
+		key.Value = keyID
+		applyKeyArguments(&key, req.Params.Arguments)
+
+		res, err := client.UpdateAPIKey(key)
+		if err != nil {
+			return nil, fmt.Errorf("could not update API key: %w", err)
+		}
+
+		return mcputil.JSONToolResult("API Key", map[string]any{
+			"key":       newKeyView(key, reveal),
+			"updatedAt": res.UpdatedAt,
+		})
+	})
+}
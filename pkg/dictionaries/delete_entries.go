@@ -0,0 +1,63 @@
+package dictionaries
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/search"
+	"github.com/algolia/mcp/pkg/mcputil"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// RegisterDeleteEntries registers the delete_entries tool with the MCP server.
+func RegisterDeleteEntries(mcps *server.MCPServer, client *search.Client) {
+	deleteEntriesTool := mcp.NewTool(
+		"dictionaries_delete_entries",
+		mcp.WithDescription("Delete custom entries of a dictionary by objectID, or all custom entries when clear is true"),
+		dictionaryOption(),
+		mcp.WithString(
+			"objectIDs",
+			mcp.Description("Comma-separated list of objectIDs of the entries to delete"),
+		),
+		mcp.WithBoolean(
+			"clear",
+			mcp.Description("Whether to delete all custom entries of the dictionary, in every language, instead of the given objectIDs"),
+		),
+		waitOption(),
+	)
+
+	mcps.AddTool(deleteEntriesTool, func(_ context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		dictionary, err := parseDictionaryName(req.Params.Arguments)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		objectIDsStr, _ := req.Params.Arguments["objectIDs"].(string)
+		objectIDs := splitList(objectIDsStr)
+		clearAll, _ := req.Params.Arguments["clear"].(bool)
+
+		var res search.UpdateTaskRes
+		switch {
+		case clearAll && len(objectIDs) > 0:
+			return mcp.NewToolResultError("objectIDs and clear are mutually exclusive"), nil
+		case clearAll:
+			res, err = client.ClearDictionaryEntries(dictionary)
+		case len(objectIDs) > 0:
+			res, err = client.DeleteDictionaryEntries(dictionary, objectIDs)
+		default:
+			return mcp.NewToolResultError("objectIDs parameter is required unless clear is true"), nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("could not delete %s entries: %w", dictionary, err)
+		}
+
+		result, err := taskResult(res, req.Params.Arguments)
+		if err != nil {
+			return nil, err
+		}
+		if !clearAll {
+			result["deleted"] = objectIDs
+		}
+		return mcputil.JSONToolResult("Delete Result", result)
+	})
+}
@@ -0,0 +1,151 @@
+package dictionaries
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/search"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// RegisterAll registers all dictionary tools with the MCP server.
+// Dictionaries are application-wide, so the tools don't take an index name.
+func RegisterAll(mcps *server.MCPServer, client *search.Client) {
+	// Register all dictionary tools.
+	RegisterSearchEntries(mcps, client)
+	RegisterSaveEntries(mcps, client)
+	RegisterReplaceEntries(mcps, client)
+	RegisterDeleteEntries(mcps, client)
+	RegisterGetSettings(mcps, client)
+	RegisterSetStandardEntries(mcps, client)
+	RegisterListLanguages(mcps, client)
+}
+
+// dictionaryOption is the option naming the dictionary a tool acts on.
+func dictionaryOption() mcp.ToolOption {
+	return mcp.WithString(
+		"dictionary",
+		mcp.Description("The dictionary to act on: stopwords, plurals or compounds"),
+		mcp.Required(),
+		mcp.Enum(string(search.Stopwords), string(search.Plurals), string(search.Compounds)),
+	)
+}
+
+// entriesDescription documents the shape of the entries argument.
+const entriesDescription = `JSON array of dictionary entries. Each entry needs an objectID and a language (ISO code, e.g. "de") and:
+- stopwords: "word" and optionally "state" ("enabled" or "disabled")
+- plurals: "words", the list of forms of the word (e.g. ["mouse", "mice"])
+- compounds: "word" and "decomposition", the words it is made of (e.g. "kopfschmerztablette" → ["kopf", "schmerz", "tablette"])`
+
+// parseDictionaryName validates the dictionary argument.
+func parseDictionaryName(args map[string]any) (search.DictionaryName, error) {
+	name, _ := args["dictionary"].(string)
+	switch d := search.DictionaryName(name); d {
+	case search.Stopwords, search.Plurals, search.Compounds:
+		return d, nil
+	case "":
+		return "", fmt.Errorf("dictionary parameter is required")
+	default:
+		return "", fmt.Errorf("unknown dictionary %q, expected stopwords, plurals or compounds", name)
+	}
+}
+
+// parseEntries decodes the entries argument into typed entries of the given
+// dictionary, checking the fields each dictionary requires.
+func parseEntries(dictionary search.DictionaryName, data string) ([]search.DictionaryEntry, error) {
+	var raw []json.RawMessage
+	if err := json.Unmarshal([]byte(data), &raw); err != nil {
+		return nil, fmt.Errorf("invalid entries JSON: %w", err)
+	}
+	if len(raw) == 0 {
+		return nil, fmt.Errorf("entries must not be empty")
+	}
+
+	entries := make([]search.DictionaryEntry, 0, len(raw))
+	for i, r := range raw {
+		var (
+			entry search.DictionaryEntry
+			err   error
+		)
+		switch dictionary {
+		case search.Stopwords:
+			var s search.Stopword
+			if err = json.Unmarshal(r, &s); err == nil {
+				switch {
+				case s.Word == "":
+					err = fmt.Errorf("word is required")
+				case s.State != "enabled" && s.State != "disabled":
+					err = fmt.Errorf("state must be enabled or disabled")
+				}
+			}
+			entry = s
+		case search.Plurals:
+			var p search.Plural
+			if err = json.Unmarshal(r, &p); err == nil && len(p.Words) < 2 {
+				err = fmt.Errorf("words must list at least two forms")
+			}
+			entry = p
+		case search.Compounds:
+			var c search.Compound
+			if err = json.Unmarshal(r, &c); err == nil {
+				switch {
+				case c.Word == "":
+					err = fmt.Errorf("word is required")
+				case len(c.Decomposition) == 0:
+					err = fmt.Errorf("decomposition is required")
+				}
+			}
+			entry = c
+		}
+		if err == nil {
+			switch {
+			case entry.ObjectID() == "":
+				err = fmt.Errorf("objectID is required")
+			case entry.Language() == "":
+				err = fmt.Errorf("language is required")
+			}
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid entry %d: %w", i, err)
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+// splitList splits a comma-separated list, ignoring empty items.
+func splitList(s string) []string {
+	items := []string{}
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// waitOption is the option shared by the tools that create a task.
+func waitOption() mcp.ToolOption {
+	return mcp.WithBoolean(
+		"wait",
+		mcp.Description("Whether to wait for the task to complete"),
+	)
+}
+
+// taskResult waits for res if the wait argument is set and returns the value
+// reported by the tool.
+func taskResult(res search.UpdateTaskRes, args map[string]any) (map[string]any, error) {
+	result := map[string]any{
+		"taskID":    res.TaskID,
+		"updatedAt": res.UpdatedAt,
+	}
+	if wait, _ := args["wait"].(bool); wait {
+		if err := res.Wait(); err != nil {
+			return nil, fmt.Errorf("could not wait for task %d: %w", res.TaskID, err)
+		}
+		result["status"] = "published"
+	}
+	return result, nil
+}
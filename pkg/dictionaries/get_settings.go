@@ -0,0 +1,30 @@
+package dictionaries
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/search"
+	"github.com/algolia/mcp/pkg/mcputil"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// RegisterGetSettings registers the get_settings tool with the MCP server.
+func RegisterGetSettings(mcps *server.MCPServer, client *search.Client) {
+	getSettingsTool := mcp.NewTool(
+		"dictionaries_get_settings",
+		mcp.WithDescription("Get the languages for which the standard entries of each dictionary are turned off"),
+	)
+
+	mcps.AddTool(getSettingsTool, func(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		settings, err := client.GetDictionarySettings()
+		if err != nil {
+			return nil, fmt.Errorf("could not get dictionary settings: %w", err)
+		}
+
+		return mcputil.JSONToolResult("Dictionary Settings", map[string]any{
+			"disableStandardEntries": settings.DisableStandardEntries.Get(),
+		})
+	})
+}
@@ -0,0 +1,30 @@
+package dictionaries
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/call"
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/search"
+	"github.com/algolia/mcp/pkg/mcputil"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// RegisterListLanguages registers the list_languages tool with the MCP server.
+func RegisterListLanguages(mcps *server.MCPServer, client *search.Client) {
+	listLanguagesTool := mcp.NewTool(
+		"dictionaries_list_languages",
+		mcp.WithDescription("List the supported languages, with the dictionaries available for each and their number of custom entries"),
+	)
+
+	mcps.AddTool(listLanguagesTool, func(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		var res map[string]any
+		if err := client.CustomRequest(&res, http.MethodGet, "/1/dictionaries/*/languages", nil, call.Read); err != nil {
+			return nil, fmt.Errorf("could not list dictionary languages: %w", err)
+		}
+
+		return mcputil.JSONToolResult("Dictionary Languages", res)
+	})
+}
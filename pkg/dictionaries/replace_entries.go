@@ -0,0 +1,131 @@
+package dictionaries
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/opt"
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/search"
+	"github.com/algolia/mcp/pkg/mcputil"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// searchPageSize is the number of entries fetched per page when listing the
+// custom entries of a language.
+const searchPageSize = 1000
+
+// RegisterReplaceEntries registers the replace_entries tool with the MCP server.
+func RegisterReplaceEntries(mcps *server.MCPServer, client *search.Client) {
+	replaceEntriesTool := mcp.NewTool(
+		"dictionaries_replace_entries",
+		mcp.WithDescription("Replace the custom entries of a dictionary in one language with the given ones. Custom entries of other languages and standard entries are not affected"),
+		dictionaryOption(),
+		mcp.WithString(
+			"language",
+			mcp.Description("The language whose custom entries are replaced (ISO code, e.g. de). Every entry must have this language"),
+			mcp.Required(),
+		),
+		mcp.WithString(
+			"entries",
+			mcp.Description(entriesDescription),
+			mcp.Required(),
+		),
+		waitOption(),
+	)
+
+	mcps.AddTool(replaceEntriesTool, func(_ context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		dictionary, err := parseDictionaryName(req.Params.Arguments)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		language, _ := req.Params.Arguments["language"].(string)
+		if language == "" {
+			return mcp.NewToolResultError("language parameter is required"), nil
+		}
+		data, _ := req.Params.Arguments["entries"].(string)
+		entries, err := parseEntries(dictionary, data)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		kept := make(map[string]bool, len(entries))
+		for i, entry := range entries {
+			if entry.Language() != language {
+				return mcp.NewToolResultError(
+					fmt.Sprintf("invalid entry %d: language is %q, expected %q", i, entry.Language(), language),
+				), nil
+			}
+			kept[entry.ObjectID()] = true
+		}
+
+		current, err := customEntryIDs(client, dictionary, language)
+		if err != nil {
+			return nil, err
+		}
+		stale := []string{}
+		for _, objectID := range current {
+			if !kept[objectID] {
+				stale = append(stale, objectID)
+			}
+		}
+
+		// New entries are saved before the stale ones are deleted, so the
+		// language never goes without its custom entries.
+		res, err := client.SaveDictionaryEntries(dictionary, entries)
+		if err != nil {
+			return nil, fmt.Errorf("could not save %s entries: %w", dictionary, err)
+		}
+		result, err := taskResult(res, req.Params.Arguments)
+		if err != nil {
+			return nil, err
+		}
+		result["saved"] = len(entries)
+
+		if len(stale) > 0 {
+			res, err := client.DeleteDictionaryEntries(dictionary, stale)
+			if err != nil {
+				return nil, fmt.Errorf("entries were saved but the replaced ones could not be deleted: %w", err)
+			}
+			deleteResult, err := taskResult(res, req.Params.Arguments)
+			if err != nil {
+				return nil, err
+			}
+			result["deleteTaskID"] = deleteResult["taskID"]
+		}
+		result["deleted"] = stale
+		return mcputil.JSONToolResult("Replace Result", result)
+	})
+}
+
+// customEntryIDs lists the objectIDs of the custom entries of a dictionary in
+// one language.
+func customEntryIDs(client *search.Client, dictionary search.DictionaryName, language string) ([]string, error) {
+	objectIDs := []string{}
+	for page := 0; ; page++ {
+		res, err := client.SearchDictionaryEntries(dictionary, "", opt.Language(language), opt.Page(page), opt.HitsPerPage(searchPageSize))
+		if err != nil {
+			return nil, fmt.Errorf("could not search %s dictionary: %w", dictionary, err)
+		}
+		b, err := json.Marshal(res.Hits)
+		if err != nil {
+			return nil, fmt.Errorf("could not read %s entries: %w", dictionary, err)
+		}
+		var hits []struct {
+			ObjectID string `json:"objectID"`
+			Language string `json:"language"`
+			Type     string `json:"type"`
+		}
+		if err := json.Unmarshal(b, &hits); err != nil {
+			return nil, fmt.Errorf("could not read %s entries: %w", dictionary, err)
+		}
+		for _, hit := range hits {
+			if hit.Type == "custom" && hit.Language == language {
+				objectIDs = append(objectIDs, hit.ObjectID)
+			}
+		}
+		if len(hits) < searchPageSize || page+1 >= res.NbPages {
+			return objectIDs, nil
+		}
+	}
+}
@@ -0,0 +1,50 @@
+package dictionaries
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/search"
+	"github.com/algolia/mcp/pkg/mcputil"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// RegisterSaveEntries registers the save_entries tool with the MCP server.
+func RegisterSaveEntries(mcps *server.MCPServer, client *search.Client) {
+	saveEntriesTool := mcp.NewTool(
+		"dictionaries_save_entries",
+		mcp.WithDescription("Add custom entries to a dictionary, or update the entries with the same objectIDs. Other custom entries are kept"),
+		dictionaryOption(),
+		mcp.WithString(
+			"entries",
+			mcp.Description(entriesDescription),
+			mcp.Required(),
+		),
+		waitOption(),
+	)
+
+	mcps.AddTool(saveEntriesTool, func(_ context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		dictionary, err := parseDictionaryName(req.Params.Arguments)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		data, _ := req.Params.Arguments["entries"].(string)
+		entries, err := parseEntries(dictionary, data)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		res, err := client.SaveDictionaryEntries(dictionary, entries)
+		if err != nil {
+			return nil, fmt.Errorf("could not save %s entries: %w", dictionary, err)
+		}
+
+		result, err := taskResult(res, req.Params.Arguments)
+		if err != nil {
+			return nil, err
+		}
+		result["saved"] = len(entries)
+		return mcputil.JSONToolResult("Save Result", result)
+	})
+}
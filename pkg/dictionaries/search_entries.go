@@ -0,0 +1,63 @@
+package dictionaries
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/opt"
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/search"
+	"github.com/algolia/mcp/pkg/mcputil"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// RegisterSearchEntries registers the search_entries tool with the MCP server.
+func RegisterSearchEntries(mcps *server.MCPServer, client *search.Client) {
+	searchEntriesTool := mcp.NewTool(
+		"dictionaries_search_entries",
+		mcp.WithDescription("Search the standard and custom entries of a dictionary. Custom entries have type \"custom\""),
+		dictionaryOption(),
+		mcp.WithString(
+			"query",
+			mcp.Description("The text to search for. Empty returns all entries"),
+		),
+		mcp.WithString(
+			"language",
+			mcp.Description("Only return entries of this language (ISO code, e.g. de)"),
+		),
+		mcp.WithNumber(
+			"page",
+			mcp.Description("The page of results to return, starting at 0"),
+		),
+		mcp.WithNumber(
+			"hitsPerPage",
+			mcp.Description("The number of entries per page (defaults to 20, max 1000)"),
+		),
+	)
+
+	mcps.AddTool(searchEntriesTool, func(_ context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		dictionary, err := parseDictionaryName(req.Params.Arguments)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		query, _ := req.Params.Arguments["query"].(string)
+
+		opts := []any{}
+		if language, ok := req.Params.Arguments["language"].(string); ok && language != "" {
+			opts = append(opts, opt.Language(language))
+		}
+		if page, ok := req.Params.Arguments["page"].(float64); ok {
+			opts = append(opts, opt.Page(int(page)))
+		}
+		if hitsPerPage, ok := req.Params.Arguments["hitsPerPage"].(float64); ok {
+			opts = append(opts, opt.HitsPerPage(int(hitsPerPage)))
+		}
+
+		res, err := client.SearchDictionaryEntries(dictionary, query, opts...)
+		if err != nil {
+			return nil, fmt.Errorf("could not search %s dictionary: %w", dictionary, err)
+		}
+
+		return mcputil.JSONToolResult("Dictionary Entries", res)
+	})
+}
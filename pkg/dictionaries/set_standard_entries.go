@@ -0,0 +1,75 @@
+package dictionaries
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/opt"
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/search"
+	"github.com/algolia/mcp/pkg/mcputil"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// RegisterSetStandardEntries registers the set_standard_entries tool with the MCP server.
+func RegisterSetStandardEntries(mcps *server.MCPServer, client *search.Client) {
+	setStandardEntriesTool := mcp.NewTool(
+		"dictionaries_set_standard_entries",
+		mcp.WithDescription("Turn the standard entries of a dictionary on or off for some languages. Custom entries are always used. Other languages keep their current setting"),
+		dictionaryOption(),
+		mcp.WithString(
+			"languages",
+			mcp.Description("Comma-separated list of language ISO codes (e.g. de,nl)"),
+			mcp.Required(),
+		),
+		mcp.WithBoolean(
+			"disable",
+			mcp.Description("Whether to turn the standard entries off (true) or back on (false)"),
+			mcp.Required(),
+		),
+		waitOption(),
+	)
+
+	mcps.AddTool(setStandardEntriesTool, func(_ context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		dictionary, err := parseDictionaryName(req.Params.Arguments)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		languagesStr, _ := req.Params.Arguments["languages"].(string)
+		languages := splitList(languagesStr)
+		if len(languages) == 0 {
+			return mcp.NewToolResultError("languages parameter is required"), nil
+		}
+		disable, ok := req.Params.Arguments["disable"].(bool)
+		if !ok {
+			return mcp.NewToolResultError("disable parameter is required"), nil
+		}
+
+		// The settings are sent whole, so merge the change into the current ones.
+		current, err := client.GetDictionarySettings()
+		if err != nil {
+			return nil, fmt.Errorf("could not get dictionary settings: %w", err)
+		}
+		entries := current.DisableStandardEntries.Get()
+		if entries[string(dictionary)] == nil {
+			entries[string(dictionary)] = make(map[string]bool)
+		}
+		for _, language := range languages {
+			entries[string(dictionary)][language] = disable
+		}
+
+		res, err := client.SetDictionarySettings(search.DictionarySettings{
+			DisableStandardEntries: opt.DisableStandardEntries(entries),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("could not set dictionary settings: %w", err)
+		}
+
+		result, err := taskResult(res, req.Params.Arguments)
+		if err != nil {
+			return nil, err
+		}
+		result["disableStandardEntries"] = entries
+		return mcputil.JSONToolResult("Dictionary Settings", result)
+	})
+}
@@ -0,0 +1,68 @@
+package mcm
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/search"
+	"github.com/algolia/mcp/pkg/mcputil"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// RegisterAssignUserIDs registers the assign_user_ids tool with the MCP server.
+func RegisterAssignUserIDs(mcps *server.MCPServer, client *search.Client) {
+	assignUserIDsTool := mcp.NewTool(
+		"mcm_assign_user_ids",
+		mcp.WithDescription("Assign user IDs to a cluster, or move them from their current cluster. Moving a user ID takes time proportional to its data; use mcm_get_pending_mappings to track the migration"),
+		mcp.WithString(
+			"userIDs",
+			mcp.Description("Comma-separated list of user IDs to assign (max 1000)"),
+			mcp.Required(),
+		),
+		mcp.WithString(
+			"cluster",
+			mcp.Description("The name of the cluster to assign the user IDs to"),
+			mcp.Required(),
+		),
+	)
+
+	mcps.AddTool(assignUserIDsTool, func(_ context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		userIDsStr, _ := req.Params.Arguments["userIDs"].(string)
+		userIDs := splitList(userIDsStr)
+		if len(userIDs) == 0 {
+			return nil, fmt.Errorf("userIDs parameter is required")
+		}
+		if len(userIDs) > maxBatchUserIDs {
+			return mcp.NewToolResultError(fmt.Sprintf("at most %d user IDs can be assigned at once", maxBatchUserIDs)), nil
+		}
+		for _, userID := range userIDs {
+			if err := validateUserID(userID); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+		}
+		cluster, _ := req.Params.Arguments["cluster"].(string)
+		if cluster == "" {
+			return nil, fmt.Errorf("cluster parameter is required")
+		}
+
+		var (
+			res search.AssignUserIDRes
+			err error
+		)
+		if len(userIDs) == 1 {
+			res, err = client.AssignUserID(userIDs[0], cluster)
+		} else {
+			res, err = client.AssignUserIDs(userIDs, cluster)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("could not assign user IDs: %w", err)
+		}
+
+		return mcputil.JSONToolResult("Assign Result", map[string]any{
+			"userIDs":   userIDs,
+			"cluster":   cluster,
+			"createdAt": res.CreatedAt,
+		})
+	})
+}
@@ -0,0 +1,47 @@
+package mcm
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/opt"
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/search"
+	"github.com/algolia/mcp/pkg/mcputil"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// RegisterGetPendingMappings registers the get_pending_mappings tool with the MCP server.
+func RegisterGetPendingMappings(mcps *server.MCPServer, client *search.Client) {
+	getPendingMappingsTool := mcp.NewTool(
+		"mcm_get_pending_mappings",
+		mcp.WithDescription("Check whether user ID assignments or migrations between clusters are still in progress, and optionally list the pending user IDs per cluster"),
+		mcp.WithBoolean(
+			"listUserIDs",
+			mcp.Description("Whether to list the pending user IDs, grouped by cluster (defaults to true)"),
+		),
+	)
+
+	mcps.AddTool(getPendingMappingsTool, func(_ context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		listUserIDs := true
+		if v, ok := req.Params.Arguments["listUserIDs"].(bool); ok {
+			listUserIDs = v
+		}
+
+		res, err := client.HasPendingMappings(opt.RetrieveMappings(listUserIDs))
+		if err != nil {
+			return nil, fmt.Errorf("could not get pending mappings: %w", err)
+		}
+
+		nbPending := 0
+		for _, userIDs := range res.Clusters {
+			nbPending += len(userIDs)
+		}
+
+		return mcputil.JSONToolResult("Pending Mappings", map[string]any{
+			"pending":   res.Pending,
+			"nbPending": nbPending,
+			"clusters":  res.Clusters,
+		})
+	})
+}
@@ -0,0 +1,28 @@
+package mcm
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/search"
+	"github.com/algolia/mcp/pkg/mcputil"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// RegisterGetTopUserIDs registers the get_top_user_ids tool with the MCP server.
+func RegisterGetTopUserIDs(mcps *server.MCPServer, client *search.Client) {
+	getTopUserIDsTool := mcp.NewTool(
+		"mcm_get_top_user_ids",
+		mcp.WithDescription("Get the 10 user IDs with the most records in each cluster"),
+	)
+
+	mcps.AddTool(getTopUserIDsTool, func(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		res, err := client.GetTopUserIDs()
+		if err != nil {
+			return nil, fmt.Errorf("could not get top user IDs: %w", err)
+		}
+
+		return mcputil.JSONToolResult("Top User IDs", res.PerCluster)
+	})
+}
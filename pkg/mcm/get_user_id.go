@@ -0,0 +1,41 @@
+package mcm
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/search"
+	"github.com/algolia/mcp/pkg/mcputil"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// RegisterGetUserID registers the get_user_id tool with the MCP server.
+func RegisterGetUserID(mcps *server.MCPServer, client *search.Client) {
+	getUserIDTool := mcp.NewTool(
+		"mcm_get_user_id",
+		mcp.WithDescription("Get the cluster a user ID is assigned to, with its number of records and data size"),
+		mcp.WithString(
+			"userID",
+			mcp.Description("The user ID to look up"),
+			mcp.Required(),
+		),
+	)
+
+	mcps.AddTool(getUserIDTool, func(_ context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		userID, _ := req.Params.Arguments["userID"].(string)
+		if userID == "" {
+			return nil, fmt.Errorf("userID parameter is required")
+		}
+		if err := validateUserID(userID); err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		res, err := client.GetUserID(userID)
+		if err != nil {
+			return nil, fmt.Errorf("could not get user ID: %w", err)
+		}
+
+		return mcputil.JSONToolResult("User ID", res)
+	})
+}
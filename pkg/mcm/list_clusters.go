@@ -0,0 +1,28 @@
+package mcm
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/search"
+	"github.com/algolia/mcp/pkg/mcputil"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// RegisterListClusters registers the list_clusters tool with the MCP server.
+func RegisterListClusters(mcps *server.MCPServer, client *search.Client) {
+	listClustersTool := mcp.NewTool(
+		"mcm_list_clusters",
+		mcp.WithDescription("List the clusters of the application with their number of records, number of user IDs and data size"),
+	)
+
+	mcps.AddTool(listClustersTool, func(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		res, err := client.ListClusters()
+		if err != nil {
+			return nil, fmt.Errorf("could not list clusters: %w", err)
+		}
+
+		return mcputil.JSONToolResult("Clusters", res.Clusters)
+	})
+}
@@ -0,0 +1,45 @@
+package mcm
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/opt"
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/search"
+	"github.com/algolia/mcp/pkg/mcputil"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// RegisterListUserIDs registers the list_user_ids tool with the MCP server.
+func RegisterListUserIDs(mcps *server.MCPServer, client *search.Client) {
+	listUserIDsTool := mcp.NewTool(
+		"mcm_list_user_ids",
+		mcp.WithDescription("List the user IDs assigned to the clusters of the application, with their cluster, number of records and data size"),
+		mcp.WithNumber(
+			"page",
+			mcp.Description("The page of results to return, starting at 0"),
+		),
+		mcp.WithNumber(
+			"hitsPerPage",
+			mcp.Description("The number of user IDs per page (defaults to 100)"),
+		),
+	)
+
+	mcps.AddTool(listUserIDsTool, func(_ context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		opts := []any{}
+		if page, ok := req.Params.Arguments["page"].(float64); ok {
+			opts = append(opts, opt.Page(int(page)))
+		}
+		if hitsPerPage, ok := req.Params.Arguments["hitsPerPage"].(float64); ok {
+			opts = append(opts, opt.HitsPerPage(int(hitsPerPage)))
+		}
+
+		res, err := client.ListUserIDs(opts...)
+		if err != nil {
+			return nil, fmt.Errorf("could not list user IDs: %w", err)
+		}
+
+		return mcputil.JSONToolResult("User IDs", res)
+	})
+}
@@ -0,0 +1,63 @@
+// Package mcm provides tools for the user ID mappings of a Multi-Cluster
+// Management (MCM) application, where each user ID is assigned to one of the
+// clusters of the application.
+package mcm
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/search"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// RegisterAll registers all MCM tools with the MCP server (both read and write).
+// The client must be created with an API key that has the admin ACL.
+func RegisterAll(mcps *server.MCPServer, client *search.Client) {
+	// Register both read and write operations.
+	RegisterReadAll(mcps, client)
+	RegisterWriteAll(mcps, client)
+}
+
+// RegisterReadAll registers read-only MCM tools with the MCP server.
+func RegisterReadAll(mcps *server.MCPServer, client *search.Client) {
+	// Register read-only operations.
+	RegisterListClusters(mcps, client)
+	RegisterListUserIDs(mcps, client)
+	RegisterGetUserID(mcps, client)
+	RegisterSearchUserIDs(mcps, client)
+	RegisterGetTopUserIDs(mcps, client)
+	RegisterGetPendingMappings(mcps, client)
+}
+
+// RegisterWriteAll registers write-only MCM tools with the MCP server.
+func RegisterWriteAll(mcps *server.MCPServer, client *search.Client) {
+	// Register write operations.
+	RegisterAssignUserIDs(mcps, client)
+	RegisterRemoveUserID(mcps, client)
+}
+
+// maxBatchUserIDs is the largest number of user IDs assigned in one call.
+const maxBatchUserIDs = 1000
+
+// userIDPattern is the format of user IDs accepted by the API.
+var userIDPattern = regexp.MustCompile(`^[a-zA-Z0-9 \-*.]+$`)
+
+func validateUserID(userID string) error {
+	if !userIDPattern.MatchString(userID) {
+		return fmt.Errorf("invalid user ID %q: only letters, digits, spaces, '-', '*' and '.' are allowed", userID)
+	}
+	return nil
+}
+
+// splitList splits a comma-separated list, ignoring empty items.
+func splitList(s string) []string {
+	items := []string{}
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -0,0 +1,44 @@
+package mcm
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/search"
+	"github.com/algolia/mcp/pkg/mcputil"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// RegisterRemoveUserID registers the remove_user_id tool with the MCP server.
+func RegisterRemoveUserID(mcps *server.MCPServer, client *search.Client) {
+	removeUserIDTool := mcp.NewTool(
+		"mcm_remove_user_id",
+		mcp.WithDescription("Remove a user ID and all its records from its cluster"),
+		mcp.WithString(
+			"userID",
+			mcp.Description("The user ID to remove"),
+			mcp.Required(),
+		),
+	)
+
+	mcps.AddTool(removeUserIDTool, func(_ context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		userID, _ := req.Params.Arguments["userID"].(string)
+		if userID == "" {
+			return nil, fmt.Errorf("userID parameter is required")
+		}
+		if err := validateUserID(userID); err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		res, err := client.RemoveUserID(userID)
+		if err != nil {
+			return nil, fmt.Errorf("could not remove user ID: %w", err)
+		}
+
+		return mcputil.JSONToolResult("Remove Result", map[string]any{
+			"userID":    userID,
+			"deletedAt": res.DeletedAt,
+		})
+	})
+}
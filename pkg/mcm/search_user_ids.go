@@ -0,0 +1,63 @@
+package mcm
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/opt"
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/search"
+	"github.com/algolia/mcp/pkg/mcputil"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// RegisterSearchUserIDs registers the search_user_ids tool with the MCP server.
+func RegisterSearchUserIDs(mcps *server.MCPServer, client *search.Client) {
+	searchUserIDsTool := mcp.NewTool(
+		"mcm_search_user_ids",
+		mcp.WithDescription("Search user IDs by prefix, optionally restricted to a cluster. The user ID list is refreshed every 12 hours, so recent assignments may be missing"),
+		mcp.WithString(
+			"query",
+			mcp.Description("The text to search for in user IDs. Empty returns all user IDs"),
+		),
+		mcp.WithString(
+			"cluster",
+			mcp.Description("Only return user IDs assigned to this cluster"),
+		),
+		mcp.WithNumber(
+			"page",
+			mcp.Description("The page of results to return, starting at 0"),
+		),
+		mcp.WithNumber(
+			"hitsPerPage",
+			mcp.Description("The number of user IDs per page (defaults to 20)"),
+		),
+	)
+
+	mcps.AddTool(searchUserIDsTool, func(_ context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		query, _ := req.Params.Arguments["query"].(string)
+
+		opts := []any{}
+		if cluster, ok := req.Params.Arguments["cluster"].(string); ok && cluster != "" {
+			opts = append(opts, opt.Cluster(cluster))
+		}
+		if page, ok := req.Params.Arguments["page"].(float64); ok {
+			opts = append(opts, opt.Page(int(page)))
+		}
+		if hitsPerPage, ok := req.Params.Arguments["hitsPerPage"].(float64); ok {
+			opts = append(opts, opt.HitsPerPage(int(hitsPerPage)))
+		}
+
+		res, err := client.SearchUserIDs(query, opts...)
+		if err != nil {
+			return nil, fmt.Errorf("could not search user IDs: %w", err)
+		}
+
+		// Highlighting is of no use to the caller.
+		for i := range res.Hits {
+			res.Hits[i].HighlightResult = nil
+		}
+
+		return mcputil.JSONToolResult("User IDs", res)
+	})
+}
@@ -0,0 +1,17 @@
+package mcputil
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// DataDir returns the directory where the server keeps the local data of the
+// given kind, e.g. backups, under the user config directory or, when there is
+// none, the temporary directory.
+func DataDir(kind string) string {
+	base, err := os.UserConfigDir()
+	if err != nil {
+		base = os.TempDir()
+	}
+	return filepath.Join(base, "algolia-mcp", kind)
+}
@@ -0,0 +1,320 @@
+// Package backup exports an index (records, settings, synonyms and rules) to
+// local files and restores it.
+//
+// A backup is a directory, or a gzipped tarball of that directory, holding:
+//
+//	manifest.json  the Manifest
+//	settings.json  the index settings
+//	synonyms.json  a JSON array of synonyms
+//	rules.json     a JSON array of rules
+//	records.jsonl  one record per line
+package backup
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/search"
+
+	"github.com/algolia/mcp/pkg/mcputil"
+	"github.com/algolia/mcp/pkg/search/rules"
+	"github.com/algolia/mcp/pkg/search/synonyms"
+)
+
+// FormatVersion is the version of the backup layout written by Write.
+const FormatVersion = 1
+
+const (
+	manifestFile = "manifest.json"
+	settingsFile = "settings.json"
+	synonymsFile = "synonyms.json"
+	rulesFile    = "rules.json"
+	recordsFile  = "records.jsonl"
+)
+
+// Manifest describes a backup.
+type Manifest struct {
+	Version    int       `json:"version"`
+	AppID      string    `json:"appID"`
+	IndexName  string    `json:"indexName"`
+	CreatedAt  time.Time `json:"createdAt"`
+	NbRecords  int       `json:"nbRecords"`
+	NbSynonyms int       `json:"nbSynonyms"`
+	NbRules    int       `json:"nbRules"`
+}
+
+// DefaultDir returns the directory used when ALGOLIA_BACKUP_DIR is not set.
+func DefaultDir() string {
+	return mcputil.DataDir("backups")
+}
+
+// Write exports index into a new versioned directory under root and returns
+// its path. When tarball is true, the directory is replaced by a .tar.gz
+// archive of it. Nothing is left under root when it fails.
+func Write(index *search.Index, root string, tarball bool) (_ string, _ Manifest, err error) {
+	manifest := Manifest{
+		Version:   FormatVersion,
+		AppID:     index.GetAppID(),
+		IndexName: index.GetName(),
+		CreatedAt: time.Now().UTC(),
+	}
+
+	// Directories are named after the backup time, with a random suffix so
+	// that backups taken in the same second don't overwrite each other.
+	parent := filepath.Join(root, index.GetName())
+	if err := os.MkdirAll(parent, 0o700); err != nil {
+		return "", manifest, fmt.Errorf("could not create backup directory: %w", err)
+	}
+	dir, err := os.MkdirTemp(parent, manifest.CreatedAt.Format("20060102T150405Z")+"-*")
+	if err != nil {
+		return "", manifest, fmt.Errorf("could not create backup directory: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			_ = os.RemoveAll(dir)
+			_ = os.Remove(dir + ".tar.gz")
+		}
+	}()
+
+	settings, err := index.GetSettings()
+	if err != nil {
+		return "", manifest, fmt.Errorf("could not get settings: %w", err)
+	}
+	if err := writeJSON(filepath.Join(dir, settingsFile), settings); err != nil {
+		return "", manifest, err
+	}
+
+	syns, err := synonyms.Browse(index)
+	if err != nil {
+		return "", manifest, err
+	}
+	manifest.NbSynonyms = len(syns)
+	if err := writeJSON(filepath.Join(dir, synonymsFile), syns); err != nil {
+		return "", manifest, err
+	}
+
+	rls, err := rules.Browse(index)
+	if err != nil {
+		return "", manifest, err
+	}
+	manifest.NbRules = len(rls)
+	if err := writeJSON(filepath.Join(dir, rulesFile), rls); err != nil {
+		return "", manifest, err
+	}
+
+	if manifest.NbRecords, err = writeRecords(index, filepath.Join(dir, recordsFile)); err != nil {
+		return "", manifest, err
+	}
+
+	if err := writeJSON(filepath.Join(dir, manifestFile), manifest); err != nil {
+		return "", manifest, err
+	}
+
+	if !tarball {
+		return dir, manifest, nil
+	}
+
+	archive := dir + ".tar.gz"
+	if err := writeTarball(dir, archive); err != nil {
+		return "", manifest, err
+	}
+	if err := os.RemoveAll(dir); err != nil {
+		return "", manifest, fmt.Errorf("could not remove backup directory: %w", err)
+	}
+	return archive, manifest, nil
+}
+
+// Backup is the content of a backup loaded in memory.
+type Backup struct {
+	Manifest Manifest
+	Settings search.Settings
+	Synonyms []search.Synonym
+	Rules    []search.Rule
+	Records  []map[string]any
+}
+
+// Read loads a backup from a directory or a .tar.gz archive written by Write.
+func Read(path string) (*Backup, error) {
+	files, err := readFiles(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var b Backup
+	if err := json.Unmarshal(files[manifestFile], &b.Manifest); err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", manifestFile, err)
+	}
+	if b.Manifest.Version != FormatVersion {
+		return nil, fmt.Errorf("unsupported backup version %d", b.Manifest.Version)
+	}
+
+	if err := b.Settings.UnmarshalJSON(files[settingsFile]); err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", settingsFile, err)
+	}
+
+	var rawSynonyms search.SearchSynonymsRes
+	if err := json.Unmarshal(files[synonymsFile], &rawSynonyms.Hits); err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", synonymsFile, err)
+	}
+	if b.Synonyms, err = rawSynonyms.Synonyms(); err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", synonymsFile, err)
+	}
+
+	if err := json.Unmarshal(files[rulesFile], &b.Rules); err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", rulesFile, err)
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(files[recordsFile]))
+	scanner.Buffer(make([]byte, 0, 64*1024), 10*1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+		var record map[string]any
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			return nil, fmt.Errorf("invalid %s on line %d: %w", recordsFile, line, err)
+		}
+		b.Records = append(b.Records, record)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("could not read %s: %w", recordsFile, err)
+	}
+
+	return &b, nil
+}
+
+func writeJSON(filename string, v any) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return fmt.Errorf("could not marshal %s: %w", filepath.Base(filename), err)
+	}
+	if err := os.WriteFile(filename, data, 0o600); err != nil {
+		return fmt.Errorf("could not write %s: %w", filepath.Base(filename), err)
+	}
+	return nil
+}
+
+func writeRecords(index *search.Index, filename string) (int, error) {
+	f, err := os.OpenFile(filename, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o600)
+	if err != nil {
+		return 0, fmt.Errorf("could not create %s: %w", recordsFile, err)
+	}
+	defer f.Close()
+
+	w := bufio.NewWriter(f)
+	enc := json.NewEncoder(w)
+
+	it, err := index.BrowseObjects()
+	if err != nil {
+		return 0, fmt.Errorf("could not browse records: %w", err)
+	}
+	n := 0
+	for {
+		record, err := it.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return n, fmt.Errorf("could not browse records: %w", err)
+		}
+		if err := enc.Encode(record); err != nil {
+			return n, fmt.Errorf("could not write record: %w", err)
+		}
+		n++
+	}
+
+	if err := w.Flush(); err != nil {
+		return n, fmt.Errorf("could not write %s: %w", recordsFile, err)
+	}
+	return n, f.Close()
+}
+
+func writeTarball(dir, archive string) error {
+	f, err := os.OpenFile(archive, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o600)
+	if err != nil {
+		return fmt.Errorf("could not create archive: %w", err)
+	}
+	defer f.Close()
+
+	gz := gzip.NewWriter(f)
+	tw := tar.NewWriter(gz)
+	for _, name := range []string{manifestFile, settingsFile, synonymsFile, rulesFile, recordsFile} {
+		data, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			return fmt.Errorf("could not read %s: %w", name, err)
+		}
+		hdr := &tar.Header{Name: name, Mode: 0o600, Size: int64(len(data)), ModTime: time.Now()}
+		if err := tw.WriteHeader(hdr); err != nil {
+			return fmt.Errorf("could not write archive: %w", err)
+		}
+		if _, err := tw.Write(data); err != nil {
+			return fmt.Errorf("could not write archive: %w", err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return fmt.Errorf("could not write archive: %w", err)
+	}
+	if err := gz.Close(); err != nil {
+		return fmt.Errorf("could not write archive: %w", err)
+	}
+	return f.Close()
+}
+
+// readFiles returns the content of the backup files, keyed by name.
+func readFiles(path string) (map[string][]byte, error) {
+	files := make(map[string][]byte)
+	names := []string{manifestFile, settingsFile, synonymsFile, rulesFile, recordsFile}
+
+	if !strings.HasSuffix(path, ".tar.gz") {
+		for _, name := range names {
+			data, err := os.ReadFile(filepath.Join(path, name))
+			if err != nil {
+				return nil, fmt.Errorf("could not read %s: %w", name, err)
+			}
+			files[name] = data
+		}
+		return files, nil
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not open archive: %w", err)
+	}
+	defer f.Close()
+
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return nil, fmt.Errorf("could not read archive: %w", err)
+	}
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("could not read archive: %w", err)
+		}
+		data, err := io.ReadAll(tr)
+		if err != nil {
+			return nil, fmt.Errorf("could not read %s from archive: %w", hdr.Name, err)
+		}
+		files[hdr.Name] = data
+	}
+
+	for _, name := range names {
+		if _, ok := files[name]; !ok {
+			return nil, fmt.Errorf("archive is missing %s", name)
+		}
+	}
+	return files, nil
+}
@@ -0,0 +1,66 @@
+package backup
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/algolia/mcp/pkg/mcputil"
+	"github.com/algolia/mcp/pkg/search/indexcache"
+)
+
+func RegisterBackupIndex(mcps *server.MCPServer, cache *indexcache.Cache, backupDir string) {
+	backupIndexTool := mcp.NewTool(
+		"backup_index",
+		mcp.WithDescription("Export the records, settings, synonyms and rules of an index into a versioned local backup"),
+		mcp.WithString(
+			"indexName",
+			mcp.Description(indexcache.IndexNameDescription),
+		),
+		mcp.WithString(
+			"profile",
+			mcp.Description("The profile of the application to back up from (defaults to the current application)"),
+		),
+		mcp.WithString(
+			"directory",
+			mcp.Description("The directory in which to create the backup (defaults to ALGOLIA_BACKUP_DIR)"),
+		),
+		mcp.WithBoolean(
+			"tarball",
+			mcp.Description("Whether to write the backup as a .tar.gz archive instead of a directory"),
+		),
+	)
+
+	mcps.AddTool(backupIndexTool, func(_ context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		profile, _ := req.Params.Arguments["profile"].(string)
+		app, err := cache.Profile(profile)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		index, err := app.FromRequest(req)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		dir, _ := req.Params.Arguments["directory"].(string)
+		if dir == "" {
+			dir = backupDir
+		}
+		tarball, _ := req.Params.Arguments["tarball"].(bool)
+
+		path, manifest, err := Write(index, dir, tarball)
+		if err != nil {
+			return mcp.NewToolResultError(
+				fmt.Sprintf("could not back up index: %v", err),
+			), nil
+		}
+
+		return mcputil.JSONToolResult("backup", map[string]any{
+			"path":     path,
+			"manifest": manifest,
+		})
+	})
+}
@@ -0,0 +1,186 @@
+package backup
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/search"
+	"github.com/algolia/mcp/pkg/mcputil"
+	"github.com/algolia/mcp/pkg/search/indexcache"
+	"github.com/algolia/mcp/pkg/search/recordschema"
+	"github.com/algolia/mcp/pkg/search/settingshistory"
+)
+
+func RegisterRestoreIndex(mcps *server.MCPServer, cache *indexcache.Cache, history *settingshistory.Store, validator *recordschema.Validator) {
+	restoreIndexTool := mcp.NewTool(
+		"restore_index",
+		mcp.WithDescription("Restore the records, settings, synonyms and rules of a local backup into an index. "+
+			"The backup is restored into a temporary index which then replaces the target index at once, so its existing records, synonyms and rules are replaced "+
+			"and settings missing from the backup are reset. The current settings are saved to the local settings history first so they can be rolled back"),
+		mcp.WithString(
+			"path",
+			mcp.Description("The backup directory or .tar.gz archive, as returned by backup_index"),
+			mcp.Required(),
+		),
+		mcp.WithString(
+			"indexName",
+			mcp.Description("The index to restore into (defaults to the index the backup was taken from)"),
+		),
+		mcp.WithString(
+			"profile",
+			mcp.Description("The profile of the application to restore into (defaults to the current application)"),
+		),
+		mcp.WithBoolean(
+			"restoreReplicas",
+			mcp.Description("Whether to also restore the replicas setting, which creates the replica indices (defaults to false)"),
+		),
+	)
+
+	mcps.AddTool(restoreIndexTool, func(_ context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		path, _ := req.Params.Arguments["path"].(string)
+		if path == "" {
+			return mcp.NewToolResultError("path is required"), nil
+		}
+
+		b, err := Read(path)
+		if err != nil {
+			return mcp.NewToolResultError(
+				fmt.Sprintf("could not read backup: %v", err),
+			), nil
+		}
+
+		profile, _ := req.Params.Arguments["profile"].(string)
+		app, err := cache.Profile(profile)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		indexName, _ := req.Params.Arguments["indexName"].(string)
+		if indexName == "" {
+			indexName = b.Manifest.IndexName
+		}
+		index, err := app.Index(indexName)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		// A backup may be old or edited, so its records are checked like
+		// any other import before anything is written.
+		if err := validator.Validate(index.GetName(), b.Records); err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		b.Settings.Primary = nil
+
+		// Keep the current settings so the restore can be rolled back. A
+		// new index has none.
+		exists, err := index.Exists()
+		if err != nil {
+			return mcp.NewToolResultError(
+				fmt.Sprintf("could not check whether %q exists: %v", index.GetName(), err),
+			), nil
+		}
+		var snapshotID string
+		if exists {
+			snapshot, err := history.SaveIndex(index, "restore_index from "+path)
+			if err != nil {
+				return mcp.NewToolResultError(
+					fmt.Sprintf("could not snapshot settings: %v", err),
+				), nil
+			}
+			snapshotID = snapshot.ID
+		}
+
+		// Replicas are only restored on request since restoring them creates
+		// indices, and only once the target is restored as they would
+		// otherwise be attached to the temporary index.
+		replicas := b.Settings.Replicas
+		b.Settings.Replicas = nil
+		if err := restore(app.Client(), index, b); err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		if restoreReplicas, _ := req.Params.Arguments["restoreReplicas"].(bool); restoreReplicas && replicas != nil {
+			res, err := index.SetSettings(search.Settings{Replicas: replicas})
+			if err == nil {
+				err = res.Wait()
+			}
+			if err != nil {
+				return mcp.NewToolResultError(
+					fmt.Sprintf("index was restored but its replicas could not be: %v", err),
+				), nil
+			}
+		}
+
+		return mcputil.JSONToolResult("restore result", map[string]any{
+			"indexName":  index.GetName(),
+			"appID":      index.GetAppID(),
+			"restored":   b.Manifest,
+			"snapshotID": snapshotID,
+			"nbRecords":  len(b.Records),
+			"nbSynonyms": len(b.Synonyms),
+			"nbRules":    len(b.Rules),
+		})
+	})
+}
+
+// restore writes a backup into a temporary index and moves it over the target,
+// so the target switches at once from its current content to the restored one.
+// Settings start from their defaults in the temporary index, so the ones
+// missing from the backup are reset.
+func restore(client *search.Client, target *search.Index, b *Backup) (err error) {
+	tmp := client.InitIndex(fmt.Sprintf("%s_tmp_restore_%d", target.GetName(), time.Now().UnixNano()))
+	defer func() {
+		if err != nil {
+			_, _ = tmp.Delete()
+		}
+	}()
+
+	settingsRes, err := tmp.SetSettings(b.Settings)
+	if err == nil {
+		err = settingsRes.Wait()
+	}
+	if err != nil {
+		return fmt.Errorf("could not restore settings: %w", err)
+	}
+
+	if len(b.Synonyms) > 0 {
+		synonymsRes, err := tmp.SaveSynonyms(b.Synonyms)
+		if err == nil {
+			err = synonymsRes.Wait()
+		}
+		if err != nil {
+			return fmt.Errorf("could not restore synonyms: %w", err)
+		}
+	}
+
+	if len(b.Rules) > 0 {
+		rulesRes, err := tmp.SaveRules(b.Rules)
+		if err == nil {
+			err = rulesRes.Wait()
+		}
+		if err != nil {
+			return fmt.Errorf("could not restore rules: %w", err)
+		}
+	}
+
+	recordsRes, err := tmp.SaveObjects(b.Records)
+	if err == nil {
+		err = recordsRes.Wait()
+	}
+	if err != nil {
+		return fmt.Errorf("could not restore records: %w", err)
+	}
+
+	moveRes, err := client.MoveIndex(tmp.GetName(), target.GetName())
+	if err == nil {
+		err = moveRes.Wait()
+	}
+	if err != nil {
+		return fmt.Errorf("could not move the restored index over %q: %w", target.GetName(), err)
+	}
+	return nil
+}
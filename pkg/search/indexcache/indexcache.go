@@ -0,0 +1,189 @@
+// Package indexcache resolves the index a Search tool acts on.
+//
+// Every Search tool accepts an optional indexName argument. The Cache turns
+// that name into a *search.Index, falling back to the default index, reusing
+// indices that were already initialized and enforcing the allow and deny lists
+// of index name patterns.
+package indexcache
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"strings"
+	"sync"
+
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/search"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// IndexNameDescription is the description of the indexName argument shared by
+// the Search tools.
+const IndexNameDescription = "The index to act on (defaults to ALGOLIA_INDEX_NAME)"
+
+// Cache holds the Search client and the indices initialized from it.
+type Cache struct {
+	client       *search.Client
+	appID        string
+	apiKey       string
+	defaultIndex string
+	allow        []string
+	deny         []string
+
+	mu       sync.Mutex
+	indices  map[string]*search.Index
+	profiles map[string]Profile
+	others   map[string]*Cache
+}
+
+// Profile holds the credentials of an Algolia application other than the
+// default one, for tools that work across applications.
+type Profile struct {
+	AppID     string `json:"appID"`
+	APIKey    string `json:"apiKey"`
+	IndexName string `json:"indexName"`
+}
+
+// LoadProfiles reads named profiles from a JSON file of the form
+// {"staging": {"appID": "...", "apiKey": "...", "indexName": "..."}}.
+func LoadProfiles(filename string) (map[string]Profile, error) {
+	b, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("could not read profiles: %w", err)
+	}
+	var profiles map[string]Profile
+	if err := json.Unmarshal(b, &profiles); err != nil {
+		return nil, fmt.Errorf("could not parse profiles: %w", err)
+	}
+	return profiles, nil
+}
+
+// New creates a Cache for the given application. allow and deny are lists of
+// index name patterns using path.Match syntax (e.g. "products_*"). When allow
+// is empty every index is allowed unless it matches a deny pattern.
+func New(appID, apiKey, defaultIndex string, allow, deny []string) *Cache {
+	return &Cache{
+		client:       search.NewClient(appID, apiKey),
+		appID:        appID,
+		apiKey:       apiKey,
+		defaultIndex: defaultIndex,
+		allow:        allow,
+		deny:         deny,
+		indices:      make(map[string]*search.Index),
+		others:       make(map[string]*Cache),
+	}
+}
+
+// SetProfiles registers the profiles that Profile can switch to.
+func (c *Cache) SetProfiles(profiles map[string]Profile) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.profiles = profiles
+}
+
+// Profile returns the Cache of the application of the named profile, or c
+// itself if name is empty. The allow and deny lists of c apply to it too.
+func (c *Cache) Profile(name string) (*Cache, error) {
+	if name == "" {
+		return c, nil
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if other, ok := c.others[name]; ok {
+		return other, nil
+	}
+	p, ok := c.profiles[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown profile %q", name)
+	}
+	other := New(p.AppID, p.APIKey, p.IndexName, c.allow, c.deny)
+	c.others[name] = other
+	return other, nil
+}
+
+// ParsePatterns splits a comma-separated list of index name patterns, as found
+// in the ALGOLIA_INDEX_ALLOWLIST and ALGOLIA_INDEX_DENYLIST environment
+// variables.
+func ParsePatterns(s string) []string {
+	var patterns []string
+	for _, p := range strings.Split(s, ",") {
+		if p = strings.TrimSpace(p); p != "" {
+			patterns = append(patterns, p)
+		}
+	}
+	return patterns
+}
+
+// Client returns the underlying Search client.
+func (c *Cache) Client() *search.Client {
+	return c.client
+}
+
+// AppID returns the application ID the client was created with.
+func (c *Cache) AppID() string {
+	return c.appID
+}
+
+// APIKey returns the API key the client was created with.
+func (c *Cache) APIKey() string {
+	return c.apiKey
+}
+
+// DefaultIndexName returns the name of the index used when a tool call
+// doesn't specify one.
+func (c *Cache) DefaultIndexName() string {
+	return c.defaultIndex
+}
+
+// Allowed reports an error if the given index name is excluded by the allow
+// or deny lists.
+func (c *Cache) Allowed(name string) error {
+	for _, p := range c.deny {
+		if ok, _ := path.Match(p, name); ok {
+			return fmt.Errorf("index %q is denied by pattern %q", name, p)
+		}
+	}
+	if len(c.allow) == 0 {
+		return nil
+	}
+	for _, p := range c.allow {
+		if ok, _ := path.Match(p, name); ok {
+			return nil
+		}
+	}
+	return fmt.Errorf("index %q is not in the allowed index patterns", name)
+}
+
+// Index returns the index with the given name, or the default index if name
+// is empty.
+func (c *Cache) Index(name string) (*search.Index, error) {
+	if name == "" {
+		name = c.defaultIndex
+	}
+	if name == "" {
+		return nil, fmt.Errorf("no indexName given and no default index configured")
+	}
+	if err := c.Allowed(name); err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	index, ok := c.indices[name]
+	if !ok {
+		index = c.client.InitIndex(name)
+		c.indices[name] = index
+	}
+	return index, nil
+}
+
+// FromRequest returns the index named by the indexName argument of a tool
+// call, or the default index if the argument is absent.
+func (c *Cache) FromRequest(req mcp.CallToolRequest) (*search.Index, error) {
+	name, _ := req.Params.Arguments["indexName"].(string)
+	return c.Index(name)
+}
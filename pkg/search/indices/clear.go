@@ -4,19 +4,28 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/algolia/algoliasearch-client-go/v3/algolia/search"
 	"github.com/algolia/mcp/pkg/mcputil"
+	"github.com/algolia/mcp/pkg/search/indexcache"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
 )
 
-func RegisterClear(mcps *server.MCPServer, index *search.Index) {
+func RegisterClear(mcps *server.MCPServer, cache *indexcache.Cache) {
 	clearIndexTool := mcp.NewTool(
 		"clear_index",
 		mcp.WithDescription("Clear an index by removing all records"),
+		mcp.WithString(
+			"indexName",
+			mcp.Description(indexcache.IndexNameDescription),
+		),
 	)
 
-	mcps.AddTool(clearIndexTool, func(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	mcps.AddTool(clearIndexTool, func(_ context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		index, err := cache.FromRequest(req)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
 		res, err := index.ClearObjects()
 		if err != nil {
 			return mcp.NewToolResultError(
@@ -0,0 +1,127 @@
+package indices
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/opt"
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/search"
+	"github.com/algolia/mcp/pkg/mcputil"
+	"github.com/algolia/mcp/pkg/search/indexcache"
+	"github.com/algolia/mcp/pkg/search/settingshistory"
+)
+
+func RegisterCreateReplica(mcps *server.MCPServer, cache *indexcache.Cache, history *settingshistory.Store) {
+	createReplicaTool := mcp.NewTool(
+		"create_replica",
+		mcp.WithDescription("Create a standard or virtual replica of an index, optionally with its own ranking settings"),
+		mcp.WithString(
+			"indexName",
+			mcp.Description("The name of the primary index (defaults to ALGOLIA_INDEX_NAME)"),
+		),
+		mcp.WithString(
+			"replicaName",
+			mcp.Description("The name of the replica index to create"),
+			mcp.Required(),
+		),
+		mcp.WithBoolean(
+			"virtual",
+			mcp.Description("Whether to create a virtual replica, which shares the records of the primary and only supports relevant sorting (defaults to false)"),
+		),
+		mcp.WithString(
+			"settings",
+			mcp.Description("Settings of the replica as a JSON string, typically its sort (e.g., {\"customRanking\":[\"asc(price)\"]} for a virtual replica, or {\"ranking\":[\"asc(price)\",\"typo\",\"geo\",\"words\",\"filters\",\"proximity\",\"attribute\",\"exact\",\"custom\"]} for a standard one)"),
+		),
+	)
+
+	mcps.AddTool(createReplicaTool, func(_ context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		primary, err := cache.FromRequest(req)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		name, _ := req.Params.Arguments["replicaName"].(string)
+		if name == "" {
+			return mcp.NewToolResultError("replicaName is required"), nil
+		}
+		replica, err := cache.Index(name)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		virtual, _ := req.Params.Arguments["virtual"].(bool)
+
+		var replicaSettings *search.Settings
+		if s, ok := req.Params.Arguments["settings"].(string); ok && s != "" {
+			replicaSettings = &search.Settings{}
+			if err := replicaSettings.UnmarshalJSON([]byte(s)); err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("invalid settings JSON: %v", err)), nil
+			}
+		}
+
+		settings, err := primary.GetSettings()
+		if err != nil {
+			return mcp.NewToolResultError(
+				fmt.Sprintf("could not get settings of %q: %v", primary.GetName(), err),
+			), nil
+		}
+		if p := settings.Primary.Get(); p != "" {
+			return mcp.NewToolResultError(fmt.Sprintf("%q is itself a replica of %q", primary.GetName(), p)), nil
+		}
+
+		entries := settings.Replicas.Get()
+		for _, entry := range entries {
+			if n, _ := replicaName(entry); n == name {
+				return mcp.NewToolResultError(fmt.Sprintf("%q is already a replica of %q", name, primary.GetName())), nil
+			}
+		}
+		entries = append(entries, replicaEntry(name, virtual))
+
+		// Adding the replica to the primary creates the replica index.
+		snapshot, err := snapshotSettings(history, primary, "create_replica")
+		if err != nil {
+			return mcp.NewToolResultError(
+				fmt.Sprintf("could not snapshot settings: %v", err),
+			), nil
+		}
+		res, err := primary.SetSettings(search.Settings{Replicas: opt.Replicas(entries...)})
+		if err == nil {
+			err = res.Wait()
+		}
+		if err != nil {
+			return mcp.NewToolResultError(
+				fmt.Sprintf("could not add replica to %q: %v", primary.GetName(), err),
+			), nil
+		}
+
+		result := map[string]any{
+			"primary":    primary.GetName(),
+			"snapshotID": snapshot.ID,
+			"replica":    name,
+			"virtual":    virtual,
+			"replicas":   entries,
+		}
+
+		if replicaSettings != nil {
+			replicaSnapshot, err := snapshotSettings(history, replica, "create_replica")
+			if err != nil {
+				return mcp.NewToolResultError(
+					fmt.Sprintf("replica created, but could not snapshot its settings: %v", err),
+				), nil
+			}
+			result["replicaSnapshotID"] = replicaSnapshot.ID
+
+			res, err := replica.SetSettings(*replicaSettings)
+			if err != nil {
+				return mcp.NewToolResultError(
+					fmt.Sprintf("replica created, but could not set its settings: %v", err),
+				), nil
+			}
+			result["settingsTaskID"] = res.TaskID
+		}
+
+		return mcputil.JSONToolResult("replica", result)
+	})
+}
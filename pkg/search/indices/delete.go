@@ -4,19 +4,28 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/algolia/algoliasearch-client-go/v3/algolia/search"
 	"github.com/algolia/mcp/pkg/mcputil"
+	"github.com/algolia/mcp/pkg/search/indexcache"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
 )
 
-func RegisterDelete(mcps *server.MCPServer, index *search.Index) {
+func RegisterDelete(mcps *server.MCPServer, cache *indexcache.Cache) {
 	deleteIndexTool := mcp.NewTool(
 		"delete_index",
 		mcp.WithDescription("Delete an index by removing all assets and configurations"),
+		mcp.WithString(
+			"indexName",
+			mcp.Description(indexcache.IndexNameDescription),
+		),
 	)
 
-	mcps.AddTool(deleteIndexTool, func(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	mcps.AddTool(deleteIndexTool, func(_ context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		index, err := cache.FromRequest(req)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
 		res, err := index.Delete()
 		if err != nil {
 			return mcp.NewToolResultError(
@@ -0,0 +1,82 @@
+package indices
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/algolia/mcp/pkg/mcputil"
+	"github.com/algolia/mcp/pkg/search/indexcache"
+)
+
+func RegisterDiffSettings(mcps *server.MCPServer, cache *indexcache.Cache) {
+	diffSettingsTool := mcp.NewTool(
+		"diff_settings",
+		mcp.WithDescription("Compare the settings of an index with the settings of another index, or with proposed settings"),
+		mcp.WithString(
+			"indexName",
+			mcp.Description(indexcache.IndexNameDescription),
+		),
+		mcp.WithString(
+			"otherIndexName",
+			mcp.Description("The index to compare against"),
+		),
+		mcp.WithString(
+			"proposed",
+			mcp.Description("Proposed settings to compare against, as a JSON string. Only the keys it contains are compared"),
+		),
+	)
+
+	mcps.AddTool(diffSettingsTool, func(_ context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		index, err := cache.FromRequest(req)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		otherIndexName, _ := req.Params.Arguments["otherIndexName"].(string)
+		proposedStr, _ := req.Params.Arguments["proposed"].(string)
+		if (otherIndexName == "") == (proposedStr == "") {
+			return mcp.NewToolResultError("exactly one of otherIndexName or proposed is required"), nil
+		}
+
+		before, err := getSettingsMap(index)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		var after map[string]any
+		against := otherIndexName
+		if otherIndexName != "" {
+			other, err := cache.Index(otherIndexName)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if after, err = getSettingsMap(other); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+		} else {
+			against = "proposed settings"
+			if err := json.Unmarshal([]byte(proposedStr), &after); err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("invalid proposed JSON: %v", err)), nil
+			}
+			// Keys absent from the proposal are left unchanged by a
+			// settings update, so they are not differences.
+			current := make(map[string]any, len(after))
+			for k := range after {
+				current[k] = before[k]
+			}
+			before = current
+		}
+
+		changes := diffSettings(before, after)
+		return mcputil.JSONToolResult("settings diff", map[string]any{
+			"indexName": index.GetName(),
+			"against":   against,
+			"changes":   changes,
+			"diff":      formatSettingsDiff(changes),
+		})
+	})
+}
@@ -6,17 +6,26 @@ import (
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
 
-	"github.com/algolia/algoliasearch-client-go/v3/algolia/search"
 	"github.com/algolia/mcp/pkg/mcputil"
+	"github.com/algolia/mcp/pkg/search/indexcache"
 )
 
-func RegisterGetSettings(mcps *server.MCPServer, index *search.Index) {
+func RegisterGetSettings(mcps *server.MCPServer, cache *indexcache.Cache) {
 	getSettingsTool := mcp.NewTool(
 		"get_settings",
 		mcp.WithDescription("Get the settings for the Algolia index"),
+		mcp.WithString(
+			"indexName",
+			mcp.Description(indexcache.IndexNameDescription),
+		),
 	)
 
-	mcps.AddTool(getSettingsTool, func(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	mcps.AddTool(getSettingsTool, func(_ context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		index, err := cache.FromRequest(req)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
 		settings, err := index.GetSettings()
 		if err != nil {
 			return nil, err
@@ -0,0 +1,298 @@
+package indices
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/search"
+	"github.com/algolia/mcp/pkg/analytics"
+	"github.com/algolia/mcp/pkg/mcputil"
+	"github.com/algolia/mcp/pkg/search/indexcache"
+)
+
+const (
+	// defaultTrafficDays is the default period searches are counted over.
+	defaultTrafficDays = 30
+	// defaultStaleDays is the default number of days without update after
+	// which an index is stale.
+	defaultStaleDays = 90
+	// analyticsConcurrency is the number of search counts fetched at once.
+	analyticsConcurrency = 8
+)
+
+// inventoryItem is an index of the inventory.
+type inventoryItem struct {
+	Name                 string    `json:"name"`
+	Entries              int64     `json:"entries"`
+	DataSize             int64     `json:"dataSize"`
+	FileSize             int64     `json:"fileSize"`
+	CreatedAt            time.Time `json:"createdAt"`
+	UpdatedAt            time.Time `json:"updatedAt"`
+	LastBuildTimeS       float64   `json:"lastBuildTimeS"`
+	PendingTask          bool      `json:"pendingTask"`
+	NumberOfPendingTasks int64     `json:"numberOfPendingTasks"`
+	Primary              string    `json:"primary,omitempty"`
+	Replicas             []string  `json:"replicas,omitempty"`
+	// Searches is the number of searches over the period, nil when
+	// analytics are unavailable.
+	Searches *int `json:"searches,omitempty"`
+	// HasTraffic is set when the index, or one of its replicas, was
+	// searched over the period.
+	HasTraffic     *bool  `json:"hasTraffic,omitempty"`
+	AnalyticsError string `json:"analyticsError,omitempty"`
+	Empty          bool   `json:"empty"`
+	Stale          bool   `json:"stale"`
+	Unused         bool   `json:"unused"`
+	// Reasons explain the flags.
+	Reasons []string `json:"reasons,omitempty"`
+}
+
+func RegisterInventory(mcps *server.MCPServer, cache *indexcache.Cache) {
+	inventoryTool := mcp.NewTool(
+		"index_inventory",
+		mcp.WithDescription("List the indices of the application with their record count, data size, creation and update dates, last build time, pending tasks, primary/replica relationships "+
+			"and number of searches over the last days, and flag the empty, stale (not updated for a while) and unused (not searched, nor any of its replicas) ones. "+
+			"Use it to find abandoned indices to clean up"),
+		mcp.WithString(
+			"pattern",
+			mcp.Description("Only list the indices whose name matches this glob pattern (e.g., test_*)"),
+		),
+		mcp.WithNumber(
+			"days",
+			mcp.Description(fmt.Sprintf("The number of days over which to count searches (default: %d)", defaultTrafficDays)),
+		),
+		mcp.WithNumber(
+			"staleDays",
+			mcp.Description(fmt.Sprintf("The number of days without update after which an index is stale (default: %d)", defaultStaleDays)),
+		),
+		mcp.WithBoolean(
+			"onlyFlagged",
+			mcp.Description("Only list the indices flagged as empty, stale or unused"),
+		),
+	)
+
+	mcps.AddTool(inventoryTool, func(_ context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		pattern, _ := req.Params.Arguments["pattern"].(string)
+		if pattern != "" {
+			if _, err := path.Match(pattern, ""); err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("invalid pattern: %v", err)), nil
+			}
+		}
+		days := defaultTrafficDays
+		if n, ok := req.Params.Arguments["days"].(float64); ok {
+			if n < 1 {
+				return mcp.NewToolResultError("days must be at least 1"), nil
+			}
+			days = int(n)
+		}
+		staleDays := defaultStaleDays
+		if n, ok := req.Params.Arguments["staleDays"].(float64); ok {
+			if n < 1 {
+				return mcp.NewToolResultError("staleDays must be at least 1"), nil
+			}
+			staleDays = int(n)
+		}
+		onlyFlagged, _ := req.Params.Arguments["onlyFlagged"].(bool)
+
+		res, err := cache.Client().ListIndices()
+		if err != nil {
+			return mcp.NewToolResultError(
+				fmt.Sprintf("could not list indices: %v", err),
+			), nil
+		}
+
+		byName := map[string]*inventoryItem{}
+		for _, ix := range res.Items {
+			if cache.Allowed(ix.Name) != nil {
+				continue
+			}
+			byName[ix.Name] = newInventoryItem(ix)
+		}
+		listed := []*inventoryItem{}
+		for name, item := range byName {
+			if ok, _ := path.Match(pattern, name); pattern == "" || ok {
+				listed = append(listed, item)
+			}
+		}
+
+		// Searches are also counted on the replicas of the listed indices,
+		// even when they aren't listed, as they count as traffic of their
+		// primary.
+		counted := map[string]*inventoryItem{}
+		for _, item := range listed {
+			counted[item.Name] = item
+			for _, name := range item.Replicas {
+				if replica, ok := byName[name]; ok {
+					counted[name] = replica
+				}
+			}
+		}
+
+		now := time.Now()
+		warnings := []string{}
+		if err := countSearches(cache.AppID(), cache.APIKey(), counted, now, days); err != nil {
+			warnings = append(warnings, fmt.Sprintf("analytics are unavailable, indices aren't flagged as unused: %v", err))
+		}
+
+		items := []*inventoryItem{}
+		for _, item := range listed {
+			flag(item, byName, now, days, staleDays)
+			if onlyFlagged && !item.Empty && !item.Stale && !item.Unused {
+				continue
+			}
+			items = append(items, item)
+		}
+		sort.Slice(items, func(i, j int) bool { return items[i].Name < items[j].Name })
+
+		var nbEmpty, nbStale, nbUnused int
+		var dataSize, unusedDataSize int64
+		for _, item := range items {
+			dataSize += item.DataSize
+			if item.Empty {
+				nbEmpty++
+			}
+			if item.Stale {
+				nbStale++
+			}
+			if item.Unused {
+				nbUnused++
+				unusedDataSize += item.DataSize
+			}
+		}
+
+		return mcputil.JSONToolResult("index inventory", map[string]any{
+			"trafficDays": days,
+			"staleDays":   staleDays,
+			"warnings":    warnings,
+			"summary": map[string]any{
+				"nbIndices":      len(items),
+				"nbEmpty":        nbEmpty,
+				"nbStale":        nbStale,
+				"nbUnused":       nbUnused,
+				"dataSize":       dataSize,
+				"unusedDataSize": unusedDataSize,
+			},
+			"indices": items,
+		})
+	})
+}
+
+func newInventoryItem(ix search.IndexRes) *inventoryItem {
+	return &inventoryItem{
+		Name:                 ix.Name,
+		Entries:              ix.Entries,
+		DataSize:             ix.DataSize,
+		FileSize:             ix.FileSize,
+		CreatedAt:            ix.CreatedAt,
+		UpdatedAt:            ix.UpdatedAt,
+		LastBuildTimeS:       ix.LastBuildTime.Seconds(),
+		PendingTask:          ix.PendingTask,
+		NumberOfPendingTasks: ix.NumberOfPendingTasks,
+		Primary:              ix.Primary,
+		Replicas:             ix.Replicas,
+	}
+}
+
+// countSearches sets the number of searches of every index over the last
+// days. It returns an error when analytics can't be read at all, and records
+// the errors of single indices on them otherwise.
+func countSearches(appID, apiKey string, items map[string]*inventoryItem, now time.Time, days int) error {
+	params := func(name string) url.Values {
+		params := url.Values{}
+		params.Set("index", name)
+		params.Set("startDate", now.AddDate(0, 0, -days).Format(time.DateOnly))
+		params.Set("endDate", now.Format(time.DateOnly))
+		return params
+	}
+	count := func(item *inventoryItem) error {
+		var res struct {
+			Count int `json:"count"`
+		}
+		if err := analytics.Get(appID, apiKey, "/2/searches/count", params(item.Name), &res); err != nil {
+			return err
+		}
+		item.Searches = &res.Count
+		return nil
+	}
+
+	names := make([]string, 0, len(items))
+	for name := range items {
+		names = append(names, name)
+	}
+	if len(names) == 0 {
+		return nil
+	}
+	sort.Strings(names)
+
+	// The first request tells whether analytics are readable at all, e.g.
+	// with the analytics ACL, before sending the others.
+	if err := count(items[names[0]]); err != nil {
+		return err
+	}
+
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, analyticsConcurrency)
+	for _, name := range names[1:] {
+		item := items[name]
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			if err := count(item); err != nil {
+				item.AnalyticsError = err.Error()
+			}
+		}()
+	}
+	wg.Wait()
+	return nil
+}
+
+// flag sets the empty, stale and unused flags of an index.
+func flag(item *inventoryItem, items map[string]*inventoryItem, now time.Time, days, staleDays int) {
+	item.Reasons = nil
+	if item.Entries == 0 {
+		item.Empty = true
+		item.Reasons = append(item.Reasons, "has no records")
+	}
+
+	if !item.UpdatedAt.IsZero() && now.Sub(item.UpdatedAt) > time.Duration(staleDays)*24*time.Hour {
+		item.Stale = true
+		item.Reasons = append(item.Reasons, fmt.Sprintf("not updated for %d days", int(now.Sub(item.UpdatedAt).Hours()/24)))
+	}
+
+	if item.Searches == nil {
+		return
+	}
+	traffic := *item.Searches > 0
+	searchedReplicas := []string{}
+	for _, name := range item.Replicas {
+		if replica, ok := items[name]; ok && replica.Searches != nil && *replica.Searches > 0 {
+			searchedReplicas = append(searchedReplicas, name)
+		}
+	}
+	traffic = traffic || len(searchedReplicas) > 0
+	item.HasTraffic = &traffic
+
+	switch {
+	case traffic:
+		if *item.Searches == 0 {
+			item.Reasons = append(item.Reasons, fmt.Sprintf("not searched directly, but its replicas are: %s", strings.Join(searchedReplicas, ", ")))
+		}
+	case !item.CreatedAt.IsZero() && now.Sub(item.CreatedAt) < time.Duration(days)*24*time.Hour:
+		// Too recent to tell.
+		item.Reasons = append(item.Reasons, fmt.Sprintf("not searched since its creation %d days ago", int(now.Sub(item.CreatedAt).Hours()/24)))
+	default:
+		item.Unused = true
+		item.Reasons = append(item.Reasons, fmt.Sprintf("not searched in the last %d days", days))
+	}
+}
@@ -6,23 +6,34 @@ import (
 
 	"github.com/algolia/algoliasearch-client-go/v3/algolia/search"
 	"github.com/algolia/mcp/pkg/mcputil"
+	"github.com/algolia/mcp/pkg/search/indexcache"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
 )
 
-func RegisterList(mcps *server.MCPServer, client *search.Client) {
+func RegisterList(mcps *server.MCPServer, cache *indexcache.Cache) {
 	listIndexTool := mcp.NewTool(
 		"list_indices",
 		mcp.WithDescription("List the indices in the application"),
 	)
 
 	mcps.AddTool(listIndexTool, func(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		res, err := client.ListIndices()
+		res, err := cache.Client().ListIndices()
 		if err != nil {
 			return mcp.NewToolResultError(
 				fmt.Sprintf("could not list indices: %v", err),
 			), nil
 		}
+
+		// Hide the indices excluded by the allow and deny lists.
+		items := make([]search.IndexRes, 0, len(res.Items))
+		for _, item := range res.Items {
+			if cache.Allowed(item.Name) == nil {
+				items = append(items, item)
+			}
+		}
+		res.Items = items
+
 		return mcputil.JSONToolResult("indices", res)
 	})
 }
@@ -0,0 +1,78 @@
+package indices
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/algolia/mcp/pkg/mcputil"
+	"github.com/algolia/mcp/pkg/search/indexcache"
+)
+
+func RegisterListReplicas(mcps *server.MCPServer, cache *indexcache.Cache) {
+	listReplicasTool := mcp.NewTool(
+		"list_replicas",
+		mcp.WithDescription("List the replica tree of an index: its primary and every replica with its type and sort settings. When given a replica, the tree of its primary is listed"),
+		mcp.WithString(
+			"indexName",
+			mcp.Description(indexcache.IndexNameDescription),
+		),
+	)
+
+	mcps.AddTool(listReplicasTool, func(_ context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		index, err := cache.FromRequest(req)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		settings, err := index.GetSettings()
+		if err != nil {
+			return mcp.NewToolResultError(
+				fmt.Sprintf("could not get settings of %q: %v", index.GetName(), err),
+			), nil
+		}
+
+		primary := index
+		if p := settings.Primary.Get(); p != "" {
+			if primary, err = cache.Index(p); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if settings, err = primary.GetSettings(); err != nil {
+				return mcp.NewToolResultError(
+					fmt.Sprintf("could not get settings of %q: %v", p, err),
+				), nil
+			}
+		}
+
+		type replicaInfo struct {
+			Name          string   `json:"name"`
+			Virtual       bool     `json:"virtual"`
+			Ranking       []string `json:"ranking,omitempty"`
+			CustomRanking []string `json:"customRanking,omitempty"`
+			Error         string   `json:"error,omitempty"`
+		}
+		replicas := []replicaInfo{}
+		for _, entry := range settings.Replicas.Get() {
+			name, virtual := replicaName(entry)
+			info := replicaInfo{Name: name, Virtual: virtual}
+			if replica, err := cache.Index(name); err != nil {
+				info.Error = err.Error()
+			} else if rs, err := replica.GetSettings(); err != nil {
+				info.Error = err.Error()
+			} else {
+				info.Ranking = rs.Ranking.Get()
+				info.CustomRanking = rs.CustomRanking.Get()
+			}
+			replicas = append(replicas, info)
+		}
+
+		return mcputil.JSONToolResult("replicas", map[string]any{
+			"primary":       primary.GetName(),
+			"ranking":       settings.Ranking.Get(),
+			"customRanking": settings.CustomRanking.Get(),
+			"replicas":      replicas,
+		})
+	})
+}
@@ -0,0 +1,36 @@
+package indices
+
+import (
+	"context"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/algolia/mcp/pkg/mcputil"
+	"github.com/algolia/mcp/pkg/search/indexcache"
+	"github.com/algolia/mcp/pkg/search/settingshistory"
+)
+
+func RegisterListSettingsHistory(mcps *server.MCPServer, cache *indexcache.Cache, history *settingshistory.Store) {
+	listSettingsHistoryTool := mcp.NewTool(
+		"list_settings_history",
+		mcp.WithDescription("List the snapshots of an index's settings saved before each settings change, most recent first"),
+		mcp.WithString(
+			"indexName",
+			mcp.Description(indexcache.IndexNameDescription),
+		),
+	)
+
+	mcps.AddTool(listSettingsHistoryTool, func(_ context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		index, err := cache.FromRequest(req)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		snapshots, err := history.List(index.GetName())
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		return mcputil.JSONToolResult("settings history", snapshots)
+	})
+}
@@ -2,37 +2,28 @@ package indices
 
 import (
 	"context"
-	"fmt"
 
-	"github.com/algolia/algoliasearch-client-go/v3/algolia/search"
 	"github.com/algolia/mcp/pkg/mcputil"
+	"github.com/algolia/mcp/pkg/search/indexcache"
+	"github.com/algolia/mcp/pkg/search/recordschema"
+	"github.com/algolia/mcp/pkg/search/settingshistory"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
 )
 
-func RegisterMove(mcps *server.MCPServer, client *search.Client, index *search.Index) {
+func RegisterMove(mcps *server.MCPServer, cache *indexcache.Cache, history *settingshistory.Store, validator *recordschema.Validator) {
 	moveIndexTool := mcp.NewTool(
 		"move_index",
-		mcp.WithDescription("Move an index to another index"),
-		mcp.WithString(
-			"indexName",
-			mcp.Description("The name of the destination index"),
-			mcp.Required(),
-		),
+		append([]mcp.ToolOption{
+			mcp.WithDescription("Move an index to another index, possibly of another application. With a scope, only the settings, synonyms or rules are moved: they are copied, then cleared from the source (settings are reset to their defaults)"),
+		}, transferOptions()...)...,
 	)
 
 	mcps.AddTool(moveIndexTool, func(_ context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		dst, ok := req.Params.Arguments["indexName"].(string)
-		if !ok {
-			return mcp.NewToolResultError("invalid indexName format, expected JSON string"), nil
-		}
-
-		res, err := client.CopyIndex(index.GetName(), dst)
+		res, err := transferIndex(cache, history, validator, req, true)
 		if err != nil {
-			return mcp.NewToolResultError(
-				fmt.Sprintf("could not move index: %v", err),
-			), nil
+			return mcp.NewToolResultError(err.Error()), nil
 		}
-		return mcputil.JSONToolResult("task", res)
+		return mcputil.JSONToolResult("move result", res)
 	})
 }
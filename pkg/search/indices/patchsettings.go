@@ -0,0 +1,105 @@
+package indices
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/opt"
+	"github.com/algolia/mcp/pkg/mcputil"
+	"github.com/algolia/mcp/pkg/search/indexcache"
+	"github.com/algolia/mcp/pkg/search/settingshistory"
+)
+
+func RegisterPatchSettings(mcps *server.MCPServer, cache *indexcache.Cache, history *settingshistory.Store) {
+	patchSettingsTool := mcp.NewTool(
+		"patch_settings",
+		mcp.WithDescription("Change only the named settings of an index, leaving every other setting untouched. "+
+			"The current settings are saved to the local settings history first so the change can be rolled back"),
+		mcp.WithString(
+			"indexName",
+			mcp.Description(indexcache.IndexNameDescription),
+		),
+		mcp.WithString(
+			"settings",
+			mcp.Description("The settings to change as a JSON string (e.g., {\"customRanking\":[\"desc(popularity)\"]}). A null value resets a setting to its default"),
+			mcp.Required(),
+		),
+		mcp.WithBoolean(
+			"forwardToReplicas",
+			mcp.Description("Whether to also apply the change to the replicas of the index"),
+		),
+	)
+
+	mcps.AddTool(patchSettingsTool, func(_ context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		index, err := cache.FromRequest(req)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		settingsStr, ok := req.Params.Arguments["settings"].(string)
+		if !ok {
+			return mcp.NewToolResultError("invalid settings format, expected JSON string"), nil
+		}
+
+		var patch map[string]any
+		if err := json.Unmarshal([]byte(settingsStr), &patch); err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("invalid JSON: %v", err)), nil
+		}
+		if len(patch) == 0 {
+			return mcp.NewToolResultError("settings must contain at least one key"), nil
+		}
+		for k := range patch {
+			if readOnlySettings[k] {
+				return mcp.NewToolResultError(fmt.Sprintf("setting %q is read-only", k)), nil
+			}
+		}
+
+		// A new index has no settings to keep, and is created by the patch.
+		snapshot, err := snapshotIfExists(history, index, "patch_settings")
+		if err != nil {
+			return mcp.NewToolResultError(
+				fmt.Sprintf("could not snapshot settings: %v", err),
+			), nil
+		}
+
+		opts := []any{}
+		var replicaSnapshotIDs map[string]string
+		if forward, ok := req.Params.Arguments["forwardToReplicas"].(bool); ok {
+			opts = append(opts, opt.ForwardToReplicas(forward))
+			if forward {
+				replicaSnapshotIDs, err = snapshotReplicas(history, cache.Client(), snapshot, "patch_settings")
+				if err != nil {
+					return mcp.NewToolResultError(
+						fmt.Sprintf("could not snapshot replica settings: %v", err),
+					), nil
+				}
+			}
+		}
+
+		res, err := putSettings(cache.Client(), index.GetName(), patch, opts...)
+		if err != nil {
+			return mcp.NewToolResultError(
+				fmt.Sprintf("could not patch settings: %v", err),
+			), nil
+		}
+
+		before := make(map[string]any, len(patch))
+		for k := range patch {
+			before[k] = snapshot.Settings[k]
+		}
+		changes := diffSettings(before, patch)
+
+		return mcputil.JSONToolResult("patch result", map[string]any{
+			"indexName":          index.GetName(),
+			"taskID":             res.TaskID,
+			"snapshotID":         snapshot.ID,
+			"replicaSnapshotIDs": replicaSnapshotIDs,
+			"changes":            changes,
+			"diff":               formatSettingsDiff(changes),
+		})
+	})
+}
@@ -0,0 +1,108 @@
+package indices
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/opt"
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/search"
+	"github.com/algolia/mcp/pkg/mcputil"
+	"github.com/algolia/mcp/pkg/search/indexcache"
+	"github.com/algolia/mcp/pkg/search/settingshistory"
+)
+
+func RegisterRemoveReplica(mcps *server.MCPServer, cache *indexcache.Cache, history *settingshistory.Store) {
+	removeReplicaTool := mcp.NewTool(
+		"remove_replica",
+		mcp.WithDescription("Safely remove a replica: unlink it from its primary index first, then delete it"),
+		mcp.WithString(
+			"indexName",
+			mcp.Description("The name of the primary index (defaults to ALGOLIA_INDEX_NAME)"),
+		),
+		mcp.WithString(
+			"replicaName",
+			mcp.Description("The name of the replica index to remove"),
+			mcp.Required(),
+		),
+		mcp.WithBoolean(
+			"keepIndex",
+			mcp.Description("Whether to keep the detached replica as a regular index instead of deleting it (defaults to false)"),
+		),
+	)
+
+	mcps.AddTool(removeReplicaTool, func(_ context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		primary, err := cache.FromRequest(req)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		name, _ := req.Params.Arguments["replicaName"].(string)
+		if name == "" {
+			return mcp.NewToolResultError("replicaName is required"), nil
+		}
+		replica, err := cache.Index(name)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		settings, err := primary.GetSettings()
+		if err != nil {
+			return mcp.NewToolResultError(
+				fmt.Sprintf("could not get settings of %q: %v", primary.GetName(), err),
+			), nil
+		}
+
+		found := false
+		entries := []string{}
+		for _, entry := range settings.Replicas.Get() {
+			if n, _ := replicaName(entry); n == name {
+				found = true
+				continue
+			}
+			entries = append(entries, entry)
+		}
+		if !found {
+			return mcp.NewToolResultError(fmt.Sprintf("%q is not a replica of %q", name, primary.GetName())), nil
+		}
+
+		// Deleting a replica that is still linked to its primary fails, so
+		// unlink it and wait for the primary to be updated first.
+		snapshot, err := snapshotSettings(history, primary, "remove_replica")
+		if err != nil {
+			return mcp.NewToolResultError(
+				fmt.Sprintf("could not snapshot settings: %v", err),
+			), nil
+		}
+		res, err := primary.SetSettings(search.Settings{Replicas: opt.Replicas(entries...)})
+		if err == nil {
+			err = res.Wait()
+		}
+		if err != nil {
+			return mcp.NewToolResultError(
+				fmt.Sprintf("could not unlink replica from %q: %v", primary.GetName(), err),
+			), nil
+		}
+
+		result := map[string]any{
+			"primary":    primary.GetName(),
+			"snapshotID": snapshot.ID,
+			"replica":    name,
+			"replicas":   entries,
+			"deleted":    false,
+		}
+
+		if keep, _ := req.Params.Arguments["keepIndex"].(bool); !keep {
+			if _, err := replica.Delete(); err != nil {
+				return mcp.NewToolResultError(
+					fmt.Sprintf("replica unlinked, but could not delete it: %v", err),
+				), nil
+			}
+			result["deleted"] = true
+		}
+
+		return mcputil.JSONToolResult("replica", result)
+	})
+}
@@ -0,0 +1,20 @@
+package indices
+
+import "strings"
+
+// replicaName returns the index name of an entry of the replicas setting,
+// and whether it is a virtual replica.
+func replicaName(entry string) (string, bool) {
+	if strings.HasPrefix(entry, "virtual(") && strings.HasSuffix(entry, ")") {
+		return strings.TrimSuffix(strings.TrimPrefix(entry, "virtual("), ")"), true
+	}
+	return entry, false
+}
+
+// replicaEntry returns the entry of the replicas setting for a replica.
+func replicaEntry(name string, virtual bool) string {
+	if virtual {
+		return "virtual(" + name + ")"
+	}
+	return name
+}
@@ -0,0 +1,80 @@
+package indices
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/algolia/mcp/pkg/mcputil"
+	"github.com/algolia/mcp/pkg/search/indexcache"
+	"github.com/algolia/mcp/pkg/search/settingshistory"
+)
+
+func RegisterRollbackSettings(mcps *server.MCPServer, cache *indexcache.Cache, history *settingshistory.Store) {
+	rollbackSettingsTool := mcp.NewTool(
+		"rollback_settings",
+		mcp.WithDescription("Restore the settings of an index from the local settings history. "+
+			"The current settings are saved to the history first, so a rollback can itself be rolled back"),
+		mcp.WithString(
+			"indexName",
+			mcp.Description(indexcache.IndexNameDescription),
+		),
+		mcp.WithString(
+			"snapshotID",
+			mcp.Description("The ID of the snapshot to restore, as returned by list_settings_history (defaults to the most recent one)"),
+		),
+	)
+
+	mcps.AddTool(rollbackSettingsTool, func(_ context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		index, err := cache.FromRequest(req)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		snapshotID, _ := req.Params.Arguments["snapshotID"].(string)
+		target, err := history.Get(index.GetName(), snapshotID)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		current, err := snapshotSettings(history, index, "rollback_settings to "+target.ID)
+		if err != nil {
+			return mcp.NewToolResultError(
+				fmt.Sprintf("could not snapshot settings: %v", err),
+			), nil
+		}
+
+		// Send every setting of the snapshot, and reset the settings that
+		// were added since it was taken.
+		restore := make(map[string]any, len(target.Settings))
+		for k, v := range target.Settings {
+			if !readOnlySettings[k] {
+				restore[k] = v
+			}
+		}
+		for k := range current.Settings {
+			if _, ok := target.Settings[k]; !ok && !readOnlySettings[k] {
+				restore[k] = nil
+			}
+		}
+
+		res, err := putSettings(cache.Client(), index.GetName(), restore)
+		if err != nil {
+			return mcp.NewToolResultError(
+				fmt.Sprintf("could not restore settings: %v", err),
+			), nil
+		}
+
+		changes := diffSettings(current.Settings, target.Settings)
+		return mcputil.JSONToolResult("rollback result", map[string]any{
+			"indexName":    index.GetName(),
+			"taskID":       res.TaskID,
+			"restoredFrom": target.ID,
+			"snapshotID":   current.ID,
+			"changes":      changes,
+			"diff":         formatSettingsDiff(changes),
+		})
+	})
+}
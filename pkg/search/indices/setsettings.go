@@ -7,24 +7,36 @@ import (
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
 
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/opt"
 	"github.com/algolia/algoliasearch-client-go/v3/algolia/search"
 	"github.com/algolia/mcp/pkg/mcputil"
+	"github.com/algolia/mcp/pkg/search/indexcache"
+	"github.com/algolia/mcp/pkg/search/settingshistory"
 )
 
-func RegisterSetSettings(mcps *server.MCPServer, writeIndex *search.Index) {
+func RegisterSetSettings(mcps *server.MCPServer, cache *indexcache.Cache, history *settingshistory.Store) {
 	setSettingTool := mcp.NewTool(
 		"set_settings",
-		mcp.WithDescription("Change the settings for the Algolia index"),
+		mcp.WithDescription("Change the settings for the Algolia index. The current settings are saved to the local settings history first"),
+		mcp.WithString(
+			"indexName",
+			mcp.Description(indexcache.IndexNameDescription),
+		),
 		mcp.WithString(
 			"object",
 			mcp.Description("The object to insert or update as a JSON string"),
 			mcp.Required(),
 		),
+		mcp.WithBoolean(
+			"forwardToReplicas",
+			mcp.Description("Whether to also apply the change to the replicas of the index"),
+		),
 	)
 
 	mcps.AddTool(setSettingTool, func(_ context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		if writeIndex == nil {
-			return mcp.NewToolResultError("write API key not set, cannot insert objects"), nil
+		index, err := cache.FromRequest(req)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
 		}
 
 		objStr, ok := req.Params.Arguments["object"].(string)
@@ -35,15 +47,46 @@ func RegisterSetSettings(mcps *server.MCPServer, writeIndex *search.Index) {
 		// Parse the JSON string into an object
 		var settings search.Settings
 		if err := settings.UnmarshalJSON([]byte(objStr)); err != nil {
-			return nil, fmt.Errorf("could not parse settings: %w", err)
+			return mcp.NewToolResultError(
+				fmt.Sprintf("could not parse settings: %v", err),
+			), nil
+		}
+
+		// Keep the current settings so the change can be rolled back. A new
+		// index has none, and is created by the change.
+		snapshot, err := snapshotIfExists(history, index, "set_settings")
+		if err != nil {
+			return mcp.NewToolResultError(
+				fmt.Sprintf("could not snapshot settings: %v", err),
+			), nil
+		}
+
+		opts := []any{}
+		var replicaSnapshotIDs map[string]string
+		if forward, ok := req.Params.Arguments["forwardToReplicas"].(bool); ok {
+			opts = append(opts, opt.ForwardToReplicas(forward))
+			if forward {
+				replicaSnapshotIDs, err = snapshotReplicas(history, cache.Client(), snapshot, "set_settings")
+				if err != nil {
+					return mcp.NewToolResultError(
+						fmt.Sprintf("could not snapshot replica settings: %v", err),
+					), nil
+				}
+			}
 		}
 
 		// Save the settings to the index
-		res, err := writeIndex.SetSettings(settings)
+		res, err := index.SetSettings(settings, opts...)
 		if err != nil {
-			return nil, fmt.Errorf("could not save object: %w", err)
+			return mcp.NewToolResultError(
+				fmt.Sprintf("could not save object: %v", err),
+			), nil
 		}
 
-		return mcputil.JSONToolResult("insert result", res)
+		return mcputil.JSONToolResult("insert result", map[string]any{
+			"task":               res,
+			"snapshotID":         snapshot.ID,
+			"replicaSnapshotIDs": replicaSnapshotIDs,
+		})
 	})
 }
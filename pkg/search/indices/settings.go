@@ -0,0 +1,161 @@
+package indices
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/call"
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/search"
+	"github.com/algolia/mcp/pkg/search/settingshistory"
+)
+
+// readOnlySettings are returned by GetSettings but can't be written.
+var readOnlySettings = map[string]bool{
+	"primary": true,
+}
+
+// settingChange is a single difference between two settings objects.
+type settingChange struct {
+	Key    string `json:"key"`
+	Kind   string `json:"kind"` // "added", "removed" or "changed"
+	Before any    `json:"before,omitempty"`
+	After  any    `json:"after,omitempty"`
+}
+
+// getSettingsMap returns the settings of an index as a generic JSON object.
+func getSettingsMap(index *search.Index) (map[string]any, error) {
+	settings, err := index.GetSettings()
+	if err != nil {
+		return nil, fmt.Errorf("could not get settings of %q: %w", index.GetName(), err)
+	}
+	return toSettingsMap(settings)
+}
+
+// toSettingsMap converts typed settings to a generic JSON object.
+func toSettingsMap(settings search.Settings) (map[string]any, error) {
+	b, err := json.Marshal(settings)
+	if err != nil {
+		return nil, fmt.Errorf("could not marshal settings: %w", err)
+	}
+	var m map[string]any
+	if err := json.Unmarshal(b, &m); err != nil {
+		return nil, fmt.Errorf("could not unmarshal settings: %w", err)
+	}
+	return m, nil
+}
+
+// putSettings sends raw settings to an index. Unlike SetSettings, null values
+// are kept so that they reset the corresponding setting to its default.
+func putSettings(client *search.Client, indexName string, settings map[string]any, opts ...any) (search.UpdateTaskRes, error) {
+	var res search.UpdateTaskRes
+	path := fmt.Sprintf("/1/indexes/%s/settings", url.PathEscape(indexName))
+	err := client.CustomRequest(&res, http.MethodPut, path, settings, call.Write, opts...)
+	return res, err
+}
+
+// snapshotSettings stores the current settings of an index in the history.
+func snapshotSettings(history *settingshistory.Store, index *search.Index, reason string) (settingshistory.Snapshot, error) {
+	return history.SaveIndex(index, reason)
+}
+
+// snapshotIfExists stores the current settings of an index about to be
+// overwritten, unless it doesn't exist yet. The snapshot is empty, without ID
+// nor settings, for a new index.
+func snapshotIfExists(history *settingshistory.Store, index *search.Index, reason string) (settingshistory.Snapshot, error) {
+	exists, err := index.Exists()
+	if err != nil {
+		return settingshistory.Snapshot{}, fmt.Errorf("could not check whether %q exists: %w", index.GetName(), err)
+	}
+	if !exists {
+		return settingshistory.Snapshot{}, nil
+	}
+	snapshot, err := snapshotSettings(history, index, reason)
+	if err != nil {
+		return settingshistory.Snapshot{}, fmt.Errorf("could not snapshot settings of %q: %w", index.GetName(), err)
+	}
+	return snapshot, nil
+}
+
+// snapshotReplicas stores the current settings of the replicas listed in the
+// snapshot of their primary, before a change is forwarded to them. It returns
+// the snapshot IDs by replica.
+func snapshotReplicas(history *settingshistory.Store, client *search.Client, primary settingshistory.Snapshot, reason string) (map[string]string, error) {
+	ids := map[string]string{}
+	entries, _ := primary.Settings["replicas"].([]any)
+	for _, e := range entries {
+		entry, _ := e.(string)
+		name, _ := replicaName(entry)
+		snapshot, err := snapshotSettings(history, client.InitIndex(name), reason)
+		if err != nil {
+			return nil, err
+		}
+		ids[name] = snapshot.ID
+	}
+	return ids, nil
+}
+
+// diffSettings lists the keys that differ between before and after, sorted
+// by key.
+func diffSettings(before, after map[string]any) []settingChange {
+	keys := make(map[string]bool, len(before)+len(after))
+	for k := range before {
+		keys[k] = true
+	}
+	for k := range after {
+		keys[k] = true
+	}
+
+	sorted := make([]string, 0, len(keys))
+	for k := range keys {
+		sorted = append(sorted, k)
+	}
+	sort.Strings(sorted)
+
+	changes := []settingChange{}
+	for _, k := range sorted {
+		b, inBefore := before[k]
+		a, inAfter := after[k]
+		switch {
+		case inBefore && (!inAfter || a == nil) && b != nil:
+			changes = append(changes, settingChange{Key: k, Kind: "removed", Before: b})
+		case (!inBefore || b == nil) && inAfter && a != nil:
+			changes = append(changes, settingChange{Key: k, Kind: "added", After: a})
+		case !reflect.DeepEqual(a, b):
+			changes = append(changes, settingChange{Key: k, Kind: "changed", Before: b, After: a})
+		}
+	}
+	return changes
+}
+
+// formatSettingsDiff renders changes as a human-readable diff.
+func formatSettingsDiff(changes []settingChange) string {
+	if len(changes) == 0 {
+		return "no differences"
+	}
+
+	var sb strings.Builder
+	for _, c := range changes {
+		switch c.Kind {
+		case "added":
+			fmt.Fprintf(&sb, "+ %s: %s\n", c.Key, compactJSON(c.After))
+		case "removed":
+			fmt.Fprintf(&sb, "- %s: %s\n", c.Key, compactJSON(c.Before))
+		default:
+			fmt.Fprintf(&sb, "~ %s: %s -> %s\n", c.Key, compactJSON(c.Before), compactJSON(c.After))
+		}
+	}
+	return sb.String()
+}
+
+func compactJSON(v any) string {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(b)
+}
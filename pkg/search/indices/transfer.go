@@ -0,0 +1,425 @@
+package indices
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/opt"
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/search"
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/algolia/mcp/pkg/search/indexcache"
+	"github.com/algolia/mcp/pkg/search/recordschema"
+	"github.com/algolia/mcp/pkg/search/rules"
+	"github.com/algolia/mcp/pkg/search/settingshistory"
+	"github.com/algolia/mcp/pkg/search/synonyms"
+)
+
+// validationBatchSize is the number of records validated at once during a
+// copy across applications.
+const validationBatchSize = 1000
+
+// copyScopes are the parts of an index that can be copied without its records.
+var copyScopes = []string{"settings", "synonyms", "rules"}
+
+// transferOptions are the options shared by copy_index and move_index.
+func transferOptions() []mcp.ToolOption {
+	return []mcp.ToolOption{
+		mcp.WithString(
+			"indexName",
+			mcp.Description("The name of the source index (defaults to ALGOLIA_INDEX_NAME)"),
+		),
+		mcp.WithString(
+			"destination",
+			mcp.Description("The name of the destination index"),
+			mcp.Required(),
+		),
+		mcp.WithArray(
+			"scope",
+			mcp.Description("The parts of the index to transfer, among settings, synonyms and rules. When omitted, the whole index is transferred, records included. The other parts of the destination are left untouched"),
+			mcp.Items(map[string]any{"type": "string", "enum": copyScopes}),
+		),
+		mcp.WithString(
+			"profile",
+			mcp.Description("The profile of the application of the source index (defaults to the current application)"),
+		),
+		mcp.WithString(
+			"destinationProfile",
+			mcp.Description("The profile of the application of the destination index (defaults to the current application)"),
+		),
+	}
+}
+
+// parseScope reads the scope argument, given as an array or a comma-separated
+// string.
+func parseScope(args map[string]any) ([]string, error) {
+	var items []string
+	switch v := args["scope"].(type) {
+	case nil:
+	case []any:
+		for _, item := range v {
+			s, ok := item.(string)
+			if !ok {
+				return nil, fmt.Errorf("invalid scope, expected an array of strings")
+			}
+			items = append(items, s)
+		}
+	case string:
+		items = strings.Split(v, ",")
+	default:
+		return nil, fmt.Errorf("invalid scope, expected an array of strings")
+	}
+
+	scope := []string{}
+	seen := map[string]bool{}
+	for _, s := range items {
+		s = strings.TrimSpace(s)
+		if s == "" || seen[s] {
+			continue
+		}
+		if !hasScope(copyScopes, s) {
+			return nil, fmt.Errorf("invalid scope %q, expected settings, synonyms or rules", s)
+		}
+		seen[s] = true
+		scope = append(scope, s)
+	}
+	return scope, nil
+}
+
+func hasScope(scope []string, s string) bool {
+	for _, item := range scope {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
+
+// transferIndex copies or moves the source index of a copy_index or
+// move_index call to its destination. Within an application it relies on the
+// operation endpoint. Across applications, the parts in scope are read from
+// the source and written to the destination, records included when the scope
+// is empty.
+func transferIndex(cache *indexcache.Cache, history *settingshistory.Store, validator *recordschema.Validator, req mcp.CallToolRequest, move bool) (map[string]any, error) {
+	profile, _ := req.Params.Arguments["profile"].(string)
+	srcApp, err := cache.Profile(profile)
+	if err != nil {
+		return nil, err
+	}
+	src, err := srcApp.FromRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	dstProfile, _ := req.Params.Arguments["destinationProfile"].(string)
+	dstApp, err := cache.Profile(dstProfile)
+	if err != nil {
+		return nil, err
+	}
+	dstName, _ := req.Params.Arguments["destination"].(string)
+	if dstName == "" {
+		return nil, fmt.Errorf("destination parameter is required")
+	}
+	dst, err := dstApp.Index(dstName)
+	if err != nil {
+		return nil, err
+	}
+
+	scope, err := parseScope(req.Params.Arguments)
+	if err != nil {
+		return nil, err
+	}
+
+	result := map[string]any{
+		"source":      src.GetName(),
+		"destination": dst.GetName(),
+		"scope":       scope,
+	}
+
+	sameApp := src.GetAppID() == dst.GetAppID()
+	if sameApp && src.GetName() == dst.GetName() {
+		return nil, fmt.Errorf("source and destination are the same index")
+	}
+
+	// Keep the settings the transfer overwrites so they can be rolled back.
+	reason := "copy_index from " + src.GetName()
+	if move {
+		reason = "move_index from " + src.GetName()
+	}
+	if len(scope) == 0 || hasScope(scope, "settings") {
+		snapshot, err := snapshotIfExists(history, dst, reason)
+		if err != nil {
+			return nil, err
+		}
+		if snapshot.ID != "" {
+			result["snapshotID"] = snapshot.ID
+		}
+	}
+
+	if sameApp {
+		// Scopes only apply to copies, so a scoped move is a scoped copy
+		// followed by the removal of the copied parts from the source.
+		if move && len(scope) == 0 {
+			res, err := srcApp.Client().MoveIndex(src.GetName(), dst.GetName())
+			if err != nil {
+				return nil, fmt.Errorf("could not move index: %w", err)
+			}
+			result["taskID"] = res.TaskID
+			return result, nil
+		}
+		opts := []any{}
+		if len(scope) > 0 {
+			opts = append(opts, opt.Scopes(scope...))
+		}
+		res, err := srcApp.Client().CopyIndex(src.GetName(), dst.GetName(), opts...)
+		if err != nil {
+			return nil, fmt.Errorf("could not copy index: %w", err)
+		}
+		result["taskID"] = res.TaskID
+		if !move {
+			return result, nil
+		}
+		if err := res.Wait(); err != nil {
+			return nil, fmt.Errorf("could not wait for the copy: %w", err)
+		}
+	} else {
+		result["sourceAppID"] = src.GetAppID()
+		result["destinationAppID"] = dst.GetAppID()
+		copied, err := copyAcrossApps(dstApp.Client(), src, dst, scope, validator)
+		if err != nil {
+			return nil, err
+		}
+		for k, v := range copied {
+			result[k] = v
+		}
+	}
+
+	if !move {
+		return result, nil
+	}
+	if len(scope) == 0 {
+		// Only reached across applications: the whole index was copied.
+		if _, err := src.Delete(); err != nil {
+			return nil, fmt.Errorf("index was copied but the source could not be deleted: %w", err)
+		}
+		result["sourceDeleted"] = true
+		return result, nil
+	}
+	sourceSnapshotID, err := clearScope(srcApp.Client(), history, src, scope, "move_index to "+dst.GetName())
+	if err != nil {
+		return nil, fmt.Errorf("index was copied but the source could not be cleared: %w", err)
+	}
+	result["sourceCleared"] = scope
+	if sourceSnapshotID != "" {
+		result["sourceSnapshotID"] = sourceSnapshotID
+	}
+	return result, nil
+}
+
+// copyAcrossApps copies the parts of src in scope, or all of it if scope is
+// empty, to an index of another application and waits for the copy to
+// complete. A full copy is built in a temporary index of the destination
+// application, validating records as they are copied, and then moved over the
+// destination, which is left untouched when a record is invalid.
+func copyAcrossApps(dstClient *search.Client, src, dst *search.Index, scope []string, validator *recordschema.Validator) (_ map[string]any, err error) {
+	all := len(scope) == 0
+	result := map[string]any{}
+
+	target := dst
+	if all {
+		target = dstClient.InitIndex(fmt.Sprintf("%s_tmp_copy_%d", dst.GetName(), time.Now().UnixNano()))
+		defer func() {
+			if err != nil {
+				_, _ = target.Delete()
+			}
+		}()
+	}
+
+	if all || hasScope(scope, "settings") {
+		settings, err := src.GetSettings()
+		if err != nil {
+			return nil, fmt.Errorf("could not get settings: %w", err)
+		}
+		// Replicas are indices of the source application, and the primary
+		// setting can't be written.
+		settings.Primary = nil
+		if settings.Replicas != nil && len(settings.Replicas.Get()) > 0 {
+			result["replicasNotCopied"] = settings.Replicas.Get()
+		}
+		settings.Replicas = nil
+		res, err := target.SetSettings(settings)
+		if err == nil {
+			err = res.Wait()
+		}
+		if err != nil {
+			return nil, fmt.Errorf("could not copy settings: %w", err)
+		}
+	}
+
+	if all || hasScope(scope, "synonyms") {
+		syns, err := synonyms.Browse(src)
+		if err != nil {
+			return nil, err
+		}
+		res, err := target.ReplaceAllSynonyms(syns)
+		if err == nil {
+			err = res.Wait()
+		}
+		if err != nil {
+			return nil, fmt.Errorf("could not copy synonyms: %w", err)
+		}
+		result["nbSynonyms"] = len(syns)
+	}
+
+	if all || hasScope(scope, "rules") {
+		rls, err := rules.Browse(src)
+		if err != nil {
+			return nil, err
+		}
+		res, err := target.ReplaceAllRules(rls)
+		if err == nil {
+			err = res.Wait()
+		}
+		if err != nil {
+			return nil, fmt.Errorf("could not copy rules: %w", err)
+		}
+		result["nbRules"] = len(rls)
+	}
+
+	if !all {
+		return result, nil
+	}
+
+	it, err := src.BrowseObjects()
+	if err != nil {
+		return nil, fmt.Errorf("could not browse records: %w", err)
+	}
+	records := &recordStream{it: it, validator: validator, indexName: dst.GetName()}
+	res, err := target.SaveObjects(records)
+	if err == nil {
+		err = res.Wait()
+	}
+	if records.err != nil {
+		return nil, records.err
+	}
+	if err != nil {
+		return nil, fmt.Errorf("could not copy records: %w", err)
+	}
+	result["nbRecords"] = records.n
+
+	moveRes, err := dstClient.MoveIndex(target.GetName(), dst.GetName())
+	if err == nil {
+		err = moveRes.Wait()
+	}
+	if err != nil {
+		return nil, fmt.Errorf("could not move the copy over %q: %w", dst.GetName(), err)
+	}
+	return result, nil
+}
+
+// recordStream feeds browsed records to the batch methods of the client,
+// which expect a nil record rather than io.EOF at the end. Records are read
+// and validated against the destination index in batches, before any record
+// of a batch is handed out.
+type recordStream struct {
+	it        *search.ObjectIterator
+	validator *recordschema.Validator
+	indexName string
+	batch     []map[string]any
+	n         int
+	// err is the validation or browse error that stopped the stream, kept
+	// since the client only reports its message.
+	err error
+}
+
+func (s *recordStream) Next(_ ...any) (any, error) {
+	if len(s.batch) == 0 {
+		if s.err = s.fill(); s.err != nil {
+			return nil, s.err
+		}
+		if len(s.batch) == 0 {
+			return nil, nil
+		}
+	}
+	record := s.batch[0]
+	s.batch = s.batch[1:]
+	s.n++
+	return record, nil
+}
+
+// fill reads and validates the next batch of records.
+func (s *recordStream) fill() error {
+	for len(s.batch) < validationBatchSize {
+		record, err := s.it.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("could not browse records: %w", err)
+		}
+		m, ok := record.(map[string]any)
+		if !ok {
+			return fmt.Errorf("unexpected record of type %T", record)
+		}
+		s.batch = append(s.batch, m)
+	}
+	err := s.validator.Validate(s.indexName, s.batch)
+	var verr *recordschema.Error
+	if errors.As(err, &verr) {
+		// Problems are numbered from the start of the index.
+		for i := range verr.Problems {
+			verr.Problems[i].Record += s.n
+		}
+	}
+	return err
+}
+
+// clearScope removes the parts in scope from index after they were moved:
+// synonyms and rules are cleared and settings are reset to their defaults,
+// replicas excepted. The settings are saved to the history first, and the
+// snapshot ID is returned.
+func clearScope(client *search.Client, history *settingshistory.Store, index *search.Index, scope []string, reason string) (string, error) {
+	var snapshotID string
+	if hasScope(scope, "settings") {
+		snapshot, err := snapshotSettings(history, index, reason)
+		if err != nil {
+			return "", fmt.Errorf("could not snapshot settings: %w", err)
+		}
+		snapshotID = snapshot.ID
+		reset := make(map[string]any, len(snapshot.Settings))
+		for k := range snapshot.Settings {
+			if !readOnlySettings[k] && k != "replicas" {
+				reset[k] = nil
+			}
+		}
+		res, err := putSettings(client, index.GetName(), reset)
+		if err == nil {
+			err = index.WaitTask(res.TaskID)
+		}
+		if err != nil {
+			return snapshotID, fmt.Errorf("could not reset settings: %w", err)
+		}
+	}
+	if hasScope(scope, "synonyms") {
+		res, err := index.ClearSynonyms()
+		if err == nil {
+			err = res.Wait()
+		}
+		if err != nil {
+			return snapshotID, fmt.Errorf("could not clear synonyms: %w", err)
+		}
+	}
+	if hasScope(scope, "rules") {
+		res, err := index.ClearRules()
+		if err == nil {
+			err = res.Wait()
+		}
+		if err != nil {
+			return snapshotID, fmt.Errorf("could not clear rules: %w", err)
+		}
+	}
+	return snapshotID, nil
+}
@@ -0,0 +1,240 @@
+package lint
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/algolia/mcp/pkg/mcputil"
+	"github.com/algolia/mcp/pkg/search/indexcache"
+	"github.com/algolia/mcp/pkg/search/rules"
+	"github.com/algolia/mcp/pkg/search/synonyms"
+)
+
+// Severities of an issue.
+const (
+	severityError   = "error"
+	severityWarning = "warning"
+	severityInfo    = "info"
+)
+
+// checks are the available checks, in the order they are reported.
+var checks = []string{
+	"duplicateConditions",
+	"overlappingConditions",
+	"expiredRules",
+	"disabledRules",
+	"missingObjects",
+	"unknownFacets",
+	"duplicateSynonyms",
+	"shadowedSynonyms",
+	"oneWayLoops",
+	"unusedPlaceholders",
+}
+
+const (
+	// defaultMaxIssues is the default number of issues listed per check.
+	defaultMaxIssues = 50
+	// getObjectsBatchSize is the number of objects fetched per call when
+	// looking for missing objectIDs.
+	getObjectsBatchSize = 1000
+)
+
+// issue is a problem found by a check.
+type issue struct {
+	Check     string   `json:"check"`
+	Severity  string   `json:"severity"`
+	ObjectIDs []string `json:"objectIDs"`
+	Message   string   `json:"message"`
+}
+
+// checkReport is the outcome of a check.
+type checkReport struct {
+	NbIssues int     `json:"nbIssues"`
+	Issues   []issue `json:"issues"`
+}
+
+func RegisterLintRulesAndSynonyms(mcps *server.MCPServer, cache *indexcache.Cache) {
+	lintTool := mcp.NewTool(
+		"lint_rules_and_synonyms",
+		mcp.WithDescription("Fetch all the rules and synonyms of an index and report problems: duplicate or overlapping rule conditions, expired or disabled rules, promoted or hidden objectIDs that no longer exist, pattern placeholders on attributes that aren't facets, duplicate and shadowed synonyms, looping one-way synonyms, and placeholders that match no record"),
+		mcp.WithString(
+			"indexName",
+			mcp.Description(indexcache.IndexNameDescription),
+		),
+		mcp.WithString(
+			"checks",
+			mcp.Description("Comma-separated list of the checks to run, among "+strings.Join(checks, ", ")+". Defaults to all of them"),
+		),
+		mcp.WithNumber(
+			"maxIssues",
+			mcp.Description(fmt.Sprintf("Maximum number of issues listed per check (default: %d); all issues are counted", defaultMaxIssues)),
+		),
+	)
+
+	mcps.AddTool(lintTool, func(_ context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		index, err := cache.FromRequest(req)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		enabled := map[string]bool{}
+		if s, ok := req.Params.Arguments["checks"].(string); ok && s != "" {
+			for _, c := range strings.Split(s, ",") {
+				c = strings.TrimSpace(c)
+				if !slices.Contains(checks, c) {
+					return mcp.NewToolResultError(fmt.Sprintf("unknown check %q, expected one of %s", c, strings.Join(checks, ", "))), nil
+				}
+				enabled[c] = true
+			}
+		} else {
+			for _, c := range checks {
+				enabled[c] = true
+			}
+		}
+		maxIssues := defaultMaxIssues
+		if n, ok := req.Params.Arguments["maxIssues"].(float64); ok && n > 0 {
+			maxIssues = int(n)
+		}
+
+		rls, err := rules.Browse(index)
+		if err != nil {
+			return nil, err
+		}
+		syns, err := synonyms.Browse(index)
+		if err != nil {
+			return nil, err
+		}
+
+		issues := []issue{}
+		now := time.Now()
+		if enabled["duplicateConditions"] || enabled["overlappingConditions"] {
+			duplicates, overlaps := lintConditions(rls)
+			if enabled["duplicateConditions"] {
+				issues = append(issues, duplicates...)
+			}
+			if enabled["overlappingConditions"] {
+				issues = append(issues, overlaps...)
+			}
+		}
+		if enabled["expiredRules"] {
+			issues = append(issues, lintExpired(rls, now)...)
+		}
+		if enabled["disabledRules"] {
+			issues = append(issues, lintDisabled(rls)...)
+		}
+		if enabled["missingObjects"] {
+			found, err := lintMissingObjects(index, rls)
+			if err != nil {
+				return nil, err
+			}
+			issues = append(issues, found...)
+		}
+		if enabled["unknownFacets"] {
+			settings, err := index.GetSettings()
+			if err != nil {
+				return nil, fmt.Errorf("could not get settings: %w", err)
+			}
+			issues = append(issues, lintUnknownFacets(rls, settings.AttributesForFaceting.Get())...)
+		}
+		if enabled["duplicateSynonyms"] || enabled["shadowedSynonyms"] {
+			duplicates, shadowed := lintSynonymGroups(syns)
+			if enabled["duplicateSynonyms"] {
+				issues = append(issues, duplicates...)
+			}
+			if enabled["shadowedSynonyms"] {
+				issues = append(issues, shadowed...)
+			}
+		}
+		if enabled["oneWayLoops"] {
+			issues = append(issues, lintOneWayLoops(syns)...)
+		}
+		if enabled["unusedPlaceholders"] {
+			found, err := lintPlaceholders(cache.Client(), index.GetName(), syns)
+			if err != nil {
+				return nil, err
+			}
+			issues = append(issues, found...)
+		}
+
+		reports := map[string]*checkReport{}
+		summary := map[string]int{severityError: 0, severityWarning: 0, severityInfo: 0}
+		for _, c := range checks {
+			if enabled[c] {
+				reports[c] = &checkReport{Issues: []issue{}}
+			}
+		}
+		for _, is := range issues {
+			r := reports[is.Check]
+			r.NbIssues++
+			summary[is.Severity]++
+			if len(r.Issues) < maxIssues {
+				r.Issues = append(r.Issues, is)
+			}
+		}
+
+		return mcputil.JSONToolResult("rules and synonyms lint", map[string]any{
+			"indexName":  index.GetName(),
+			"nbRules":    len(rls),
+			"nbSynonyms": len(syns),
+			"nbIssues":   len(issues),
+			"summary":    summary,
+			"checks":     reports,
+		})
+	})
+}
+
+// words splits text into lowercase words, keeping placeholders such as
+// {facet:brand} or <street> whole. It differs from the tokenizer of
+// simulate_rules, which splits free-form queries: there, a stray "<" would
+// swallow the words after it, while here it opens a synonym placeholder.
+func words(s string) []string {
+	result := []string{}
+	var b strings.Builder
+	flush := func() {
+		if b.Len() > 0 {
+			result = append(result, b.String())
+			b.Reset()
+		}
+	}
+	var closing rune
+	for _, r := range strings.ToLower(s) {
+		switch {
+		case closing == 0 && (r == '{' || r == '<'):
+			flush()
+			closing = map[rune]rune{'{': '}', '<': '>'}[r]
+			b.WriteRune(r)
+		case closing != 0:
+			b.WriteRune(r)
+			if r == closing {
+				closing = 0
+				flush()
+			}
+		case isWordRune(r):
+			b.WriteRune(r)
+		default:
+			flush()
+		}
+	}
+	flush()
+	return result
+}
+
+// sortedSet returns the distinct normalized words of a list, sorted.
+func sortedSet(items []string) []string {
+	set := []string{}
+	for _, item := range items {
+		w := strings.Join(words(item), " ")
+		if w != "" && !slices.Contains(set, w) {
+			set = append(set, w)
+		}
+	}
+	sort.Strings(set)
+	return set
+}
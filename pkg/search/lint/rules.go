@@ -0,0 +1,360 @@
+package lint
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/opt"
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/search"
+)
+
+// facetPlaceholderPrefix starts the facet placeholders of a rule pattern.
+const facetPlaceholderPrefix = "{facet:"
+
+// condition is a rule condition in a comparable form.
+type condition struct {
+	RuleID       string
+	Anchoring    search.RulePatternAnchoring
+	Pattern      []string
+	Context      string
+	Filters      string
+	Alternatives bool
+}
+
+// key identifies conditions that trigger on exactly the same searches.
+func (c condition) key() string {
+	return fmt.Sprintf("%s|%s|%s|%s|%t", c.Anchoring, strings.Join(c.Pattern, " "), c.Context, c.Filters, c.Alternatives)
+}
+
+// hasPlaceholder tells whether the pattern matches facet values, which
+// can't be compared word by word.
+func (c condition) hasPlaceholder() bool {
+	return slices.ContainsFunc(c.Pattern, func(w string) bool { return strings.HasPrefix(w, facetPlaceholderPrefix) })
+}
+
+func (c condition) String() string {
+	parts := []string{}
+	if c.Anchoring != "" {
+		parts = append(parts, fmt.Sprintf("%s %q", c.Anchoring, strings.Join(c.Pattern, " ")))
+	}
+	if c.Context != "" {
+		parts = append(parts, fmt.Sprintf("context %q", c.Context))
+	}
+	if c.Filters != "" {
+		parts = append(parts, fmt.Sprintf("filters %q", c.Filters))
+	}
+	if len(parts) == 0 {
+		return "no condition"
+	}
+	return strings.Join(parts, ", ")
+}
+
+// ruleConditions returns the conditions of a rule, including the deprecated
+// single condition.
+func ruleConditions(rule search.Rule) []condition {
+	raw := rule.Conditions
+	if rule.Condition != (search.RuleCondition{}) {
+		raw = append([]search.RuleCondition{rule.Condition}, raw...)
+	}
+	conditions := make([]condition, 0, len(raw))
+	for _, c := range raw {
+		alternatives := false
+		if c.Alternatives != nil {
+			b, _ := json.Marshal(c.Alternatives)
+			alternatives = string(b) == "true"
+		}
+		conditions = append(conditions, condition{
+			RuleID:       rule.ObjectID,
+			Anchoring:    c.Anchoring,
+			Pattern:      words(c.Pattern),
+			Context:      c.Context,
+			Filters:      strings.Join(strings.Fields(c.Filters), " "),
+			Alternatives: alternatives,
+		})
+	}
+	return conditions
+}
+
+// lintConditions finds rules with identical conditions, and rules whose
+// condition is covered by another rule's, so that every search triggering
+// the first also triggers the second. Disabled rules are left out.
+func lintConditions(rules []search.Rule) ([]issue, []issue) {
+	byKey := map[string][]string{}
+	keys := []condition{}
+	// Only conditions with the same context and filters can cover each
+	// other, so they are compared within these groups.
+	groups := map[string][]condition{}
+	for _, rule := range rules {
+		if !rule.Enabled.Get() {
+			continue
+		}
+		for _, c := range ruleConditions(rule) {
+			k := c.key()
+			if _, ok := byKey[k]; !ok {
+				keys = append(keys, c)
+			}
+			if !slices.Contains(byKey[k], c.RuleID) {
+				byKey[k] = append(byKey[k], c.RuleID)
+			}
+			if c.Anchoring != "" && !c.hasPlaceholder() {
+				g := c.Context + "|" + c.Filters
+				groups[g] = append(groups[g], c)
+			}
+		}
+	}
+
+	duplicates := []issue{}
+	for _, c := range keys {
+		ids := byKey[c.key()]
+		if len(ids) < 2 {
+			continue
+		}
+		duplicates = append(duplicates, issue{
+			Check:     "duplicateConditions",
+			Severity:  severityWarning,
+			ObjectIDs: ids,
+			Message:   fmt.Sprintf("%d rules have the same condition (%s); their consequences are combined on every matching search", len(ids), c),
+		})
+	}
+
+	overlaps := []issue{}
+	seen := map[string]bool{}
+	for _, group := range groups {
+		for _, a := range group {
+			for _, b := range group {
+				if a.RuleID == b.RuleID || a.key() == b.key() || !covers(b, a) {
+					continue
+				}
+				pair := a.RuleID + "|" + b.RuleID
+				if seen[pair] {
+					continue
+				}
+				seen[pair] = true
+				overlaps = append(overlaps, issue{
+					Check:     "overlappingConditions",
+					Severity:  severityInfo,
+					ObjectIDs: []string{a.RuleID, b.RuleID},
+					Message:   fmt.Sprintf("every search triggering rule %q (%s) also triggers rule %q (%s)", a.RuleID, a, b.RuleID, b),
+				})
+			}
+		}
+	}
+	sort.Slice(overlaps, func(i, j int) bool {
+		return strings.Join(overlaps[i].ObjectIDs, "|") < strings.Join(overlaps[j].ObjectIDs, "|")
+	})
+	return duplicates, overlaps
+}
+
+// covers tells whether every query matching a also matches b.
+func covers(b, a condition) bool {
+	if !matches(b.Anchoring, b.Pattern, a.Pattern) {
+		return false
+	}
+	// b must be at most as strict as a: a's pattern matching b is only
+	// enough if the queries a accepts around its pattern still match b.
+	return b.Anchoring == search.Contains || a.Anchoring == search.Is || a.Anchoring == b.Anchoring
+}
+
+// matches tells whether a query matches a pattern with the given anchoring.
+func matches(anchoring search.RulePatternAnchoring, pattern, query []string) bool {
+	if len(pattern) == 0 {
+		return anchoring != search.Is || len(query) == 0
+	}
+	if len(query) < len(pattern) {
+		return false
+	}
+	switch anchoring {
+	case search.Is:
+		return slices.Equal(query, pattern)
+	case search.StartsWith:
+		return slices.Equal(query[:len(pattern)], pattern)
+	case search.EndsWith:
+		return slices.Equal(query[len(query)-len(pattern):], pattern)
+	default:
+		for i := 0; i+len(pattern) <= len(query); i++ {
+			if slices.Equal(query[i:i+len(pattern)], pattern) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// lintExpired finds rules whose validity periods are all over.
+func lintExpired(rules []search.Rule, now time.Time) []issue {
+	issues := []issue{}
+	for _, rule := range rules {
+		if len(rule.Validity) == 0 {
+			continue
+		}
+		var last time.Time
+		for _, r := range rule.Validity {
+			if r.Until.After(last) {
+				last = r.Until
+			}
+		}
+		if last.Before(now) {
+			issues = append(issues, issue{
+				Check:     "expiredRules",
+				Severity:  severityWarning,
+				ObjectIDs: []string{rule.ObjectID},
+				Message:   fmt.Sprintf("the rule expired on %s and will never trigger again", last.UTC().Format(time.RFC3339)),
+			})
+		}
+	}
+	return issues
+}
+
+// lintDisabled finds disabled rules, which are often leftovers.
+func lintDisabled(rules []search.Rule) []issue {
+	issues := []issue{}
+	for _, rule := range rules {
+		if !rule.Enabled.Get() {
+			issues = append(issues, issue{
+				Check:     "disabledRules",
+				Severity:  severityInfo,
+				ObjectIDs: []string{rule.ObjectID},
+				Message:   "the rule is disabled",
+			})
+		}
+	}
+	return issues
+}
+
+// lintMissingObjects finds promoted and hidden objectIDs that aren't in the
+// index anymore.
+func lintMissingObjects(index *search.Index, rules []search.Rule) ([]issue, error) {
+	promotedBy := map[string][]string{}
+	hiddenBy := map[string][]string{}
+	objectIDs := []string{}
+	add := func(refs map[string][]string, objectID, ruleID string) {
+		if _, ok := promotedBy[objectID]; !ok {
+			if _, ok := hiddenBy[objectID]; !ok {
+				objectIDs = append(objectIDs, objectID)
+			}
+		}
+		refs[objectID] = append(refs[objectID], ruleID)
+	}
+	for _, rule := range rules {
+		for _, p := range rule.Consequence.Promote {
+			if p.ObjectID != "" {
+				add(promotedBy, p.ObjectID, rule.ObjectID)
+			}
+			for _, id := range p.ObjectIDs {
+				add(promotedBy, id, rule.ObjectID)
+			}
+		}
+		for _, h := range rule.Consequence.Hide {
+			add(hiddenBy, h.ObjectID, rule.ObjectID)
+		}
+	}
+
+	missing := map[string]bool{}
+	for start := 0; start < len(objectIDs); start += getObjectsBatchSize {
+		batch := objectIDs[start:min(start+getObjectsBatchSize, len(objectIDs))]
+		var objects []map[string]any
+		if err := index.GetObjects(batch, &objects, opt.AttributesToRetrieve("objectID")); err != nil {
+			return nil, fmt.Errorf("could not get objects: %w", err)
+		}
+		for i, id := range batch {
+			if i >= len(objects) || objects[i] == nil {
+				missing[id] = true
+			}
+		}
+	}
+
+	// Report per rule, so each rule can be fixed at once.
+	promoted, hidden := map[string][]string{}, map[string][]string{}
+	ruleIDs := []string{}
+	for _, id := range objectIDs {
+		if !missing[id] {
+			continue
+		}
+		for _, ruleID := range promotedBy[id] {
+			if !slices.Contains(ruleIDs, ruleID) {
+				ruleIDs = append(ruleIDs, ruleID)
+			}
+			promoted[ruleID] = append(promoted[ruleID], id)
+		}
+		for _, ruleID := range hiddenBy[id] {
+			if !slices.Contains(ruleIDs, ruleID) {
+				ruleIDs = append(ruleIDs, ruleID)
+			}
+			hidden[ruleID] = append(hidden[ruleID], id)
+		}
+	}
+	issues := []issue{}
+	for _, ruleID := range ruleIDs {
+		if ids := promoted[ruleID]; len(ids) > 0 {
+			issues = append(issues, issue{
+				Check:     "missingObjects",
+				Severity:  severityError,
+				ObjectIDs: []string{ruleID},
+				Message:   fmt.Sprintf("the rule promotes objects that don't exist anymore: %s", strings.Join(ids, ", ")),
+			})
+		}
+		if ids := hidden[ruleID]; len(ids) > 0 {
+			issues = append(issues, issue{
+				Check:     "missingObjects",
+				Severity:  severityInfo,
+				ObjectIDs: []string{ruleID},
+				Message:   fmt.Sprintf("the rule hides objects that don't exist anymore: %s", strings.Join(ids, ", ")),
+			})
+		}
+	}
+	return issues, nil
+}
+
+// lintUnknownFacets finds facet placeholders and automatic facet filters on
+// attributes that aren't declared in attributesForFaceting.
+func lintUnknownFacets(rules []search.Rule, attributesForFaceting []string) []issue {
+	facets := map[string]bool{}
+	for _, a := range attributesForFaceting {
+		for _, modifier := range []string{"searchable(", "filterOnly(", "afterDistinct("} {
+			if strings.HasPrefix(a, modifier) {
+				a = strings.TrimSuffix(strings.TrimPrefix(a, modifier), ")")
+			}
+		}
+		facets[strings.ToLower(a)] = true
+	}
+
+	issues := []issue{}
+	for _, rule := range rules {
+		unknown := []string{}
+		for _, c := range ruleConditions(rule) {
+			for _, w := range c.Pattern {
+				if facet, ok := strings.CutPrefix(w, facetPlaceholderPrefix); ok {
+					facet = strings.TrimSuffix(facet, "}")
+					if !facets[facet] && !slices.Contains(unknown, facet) {
+						unknown = append(unknown, facet)
+					}
+				}
+			}
+		}
+		if p := rule.Consequence.Params; p != nil {
+			for _, f := range slices.Concat(p.AutomaticFacetFilters, p.AutomaticOptionalFacetFilters) {
+				if !facets[strings.ToLower(f.Facet)] && !slices.Contains(unknown, f.Facet) {
+					unknown = append(unknown, f.Facet)
+				}
+			}
+		}
+		if len(unknown) > 0 {
+			issues = append(issues, issue{
+				Check:     "unknownFacets",
+				Severity:  severityError,
+				ObjectIDs: []string{rule.ObjectID},
+				Message:   fmt.Sprintf("the rule relies on attributes that aren't in attributesForFaceting: %s", strings.Join(unknown, ", ")),
+			})
+		}
+	}
+	return issues
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
@@ -0,0 +1,284 @@
+package lint
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/search"
+	"github.com/algolia/mcp/pkg/search/query"
+)
+
+// synonymGroup is a synonym in a comparable form.
+type synonymGroup struct {
+	ObjectID string
+	Type     search.SynonymType
+	// Input is the word the synonym applies to, for one-way synonyms, alt
+	// corrections and placeholders.
+	Input string
+	Words []string
+}
+
+func (g synonymGroup) key() string {
+	return fmt.Sprintf("%s|%s|%s", g.Type, g.Input, strings.Join(g.Words, ","))
+}
+
+func toGroup(s search.Synonym) synonymGroup {
+	g := synonymGroup{ObjectID: s.ObjectID(), Type: s.Type()}
+	switch syn := s.(type) {
+	case search.RegularSynonym:
+		g.Words = sortedSet(syn.Synonyms)
+	case search.OneWaySynonym:
+		g.Input = strings.Join(words(syn.Input), " ")
+		g.Words = sortedSet(syn.Synonyms)
+	case search.AltCorrection1:
+		g.Input = strings.Join(words(syn.Word), " ")
+		g.Words = sortedSet(syn.Corrections)
+	case search.AltCorrection2:
+		g.Input = strings.Join(words(syn.Word), " ")
+		g.Words = sortedSet(syn.Corrections)
+	case search.Placeholder:
+		g.Input = strings.ToLower(syn.Placeholder)
+		g.Words = sortedSet(syn.Replacements)
+	}
+	return g
+}
+
+// lintSynonymGroups finds identical synonyms, and synonyms that add nothing
+// because a regular synonym already covers them.
+func lintSynonymGroups(synonyms []search.Synonym) ([]issue, []issue) {
+	groups := make([]synonymGroup, 0, len(synonyms))
+	regular := []synonymGroup{}
+	byKey := map[string][]string{}
+	keys := []synonymGroup{}
+	for _, s := range synonyms {
+		g := toGroup(s)
+		groups = append(groups, g)
+		if g.Type == search.RegularSynonymType {
+			regular = append(regular, g)
+		}
+		if _, ok := byKey[g.key()]; !ok {
+			keys = append(keys, g)
+		}
+		byKey[g.key()] = append(byKey[g.key()], g.ObjectID)
+	}
+
+	duplicates := []issue{}
+	for _, g := range keys {
+		ids := byKey[g.key()]
+		if len(ids) < 2 {
+			continue
+		}
+		duplicates = append(duplicates, issue{
+			Check:     "duplicateSynonyms",
+			Severity:  severityWarning,
+			ObjectIDs: ids,
+			Message:   fmt.Sprintf("%d %ss are identical (%s)", len(ids), typeNames[g.Type], describeGroup(g)),
+		})
+	}
+
+	// Regular synonyms by word, to find the groups covering another one.
+	byWord := map[string][]int{}
+	for i, g := range regular {
+		for _, w := range g.Words {
+			byWord[w] = append(byWord[w], i)
+		}
+	}
+	coveringGroup := func(g synonymGroup, required []string) (synonymGroup, bool) {
+		if len(required) == 0 {
+			return synonymGroup{}, false
+		}
+		for _, i := range byWord[required[0]] {
+			r := regular[i]
+			if r.ObjectID == g.ObjectID || r.key() == g.key() {
+				continue
+			}
+			if !slices.ContainsFunc(required, func(w string) bool { return !slices.Contains(r.Words, w) }) {
+				return r, true
+			}
+		}
+		return synonymGroup{}, false
+	}
+
+	shadowed := []issue{}
+	for _, g := range groups {
+		var required []string
+		var reason string
+		switch g.Type {
+		case search.RegularSynonymType:
+			required, reason = g.Words, "all its words are already synonyms"
+		case search.OneWaySynonymType:
+			required, reason = append([]string{g.Input}, g.Words...), "its input and synonyms are already regular synonyms"
+		case search.AltCorrection1Type, search.AltCorrection2Type:
+			required, reason = append([]string{g.Input}, g.Words...), "its word and corrections are already regular synonyms, which match without the typo penalty"
+		default:
+			continue
+		}
+		if r, ok := coveringGroup(g, required); ok {
+			shadowed = append(shadowed, issue{
+				Check:     "shadowedSynonyms",
+				Severity:  severityWarning,
+				ObjectIDs: []string{g.ObjectID, r.ObjectID},
+				Message:   fmt.Sprintf("%s %q (%s) is shadowed by regular synonym %q: %s", typeNames[g.Type], g.ObjectID, describeGroup(g), r.ObjectID, reason),
+			})
+		}
+	}
+	return duplicates, shadowed
+}
+
+// typeNames are the synonym types as written in messages.
+var typeNames = map[search.SynonymType]string{
+	search.RegularSynonymType: "regular synonym",
+	search.OneWaySynonymType:  "one-way synonym",
+	search.AltCorrection1Type: "altCorrection1",
+	search.AltCorrection2Type: "altCorrection2",
+	search.PlaceholderType:    "placeholder",
+}
+
+func describeGroup(g synonymGroup) string {
+	if g.Input == "" {
+		return strings.Join(g.Words, ", ")
+	}
+	return g.Input + " -> " + strings.Join(g.Words, ", ")
+}
+
+// lintOneWayLoops finds one-way synonyms that lead back to their input, e.g.
+// "phone" -> "mobile" and "mobile" -> "phone". Each loop is reported once.
+func lintOneWayLoops(synonyms []search.Synonym) []issue {
+	edges := map[string][]string{}
+	// edgeIDs are the objectIDs of the one-way synonyms behind each edge.
+	edgeIDs := map[string][]string{}
+	nodes := []string{}
+	for _, s := range synonyms {
+		g := toGroup(s)
+		if g.Type != search.OneWaySynonymType || g.Input == "" {
+			continue
+		}
+		if _, ok := edges[g.Input]; !ok {
+			nodes = append(nodes, g.Input)
+		}
+		for _, w := range g.Words {
+			if w == g.Input {
+				continue
+			}
+			if !slices.Contains(edges[g.Input], w) {
+				edges[g.Input] = append(edges[g.Input], w)
+			}
+			edgeIDs[g.Input+"|"+w] = append(edgeIDs[g.Input+"|"+w], g.ObjectID)
+		}
+	}
+	sort.Strings(nodes)
+
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := map[string]int{}
+	stack := []string{}
+	seen := map[string]bool{}
+	issues := []issue{}
+	var visit func(n string)
+	visit = func(n string) {
+		state[n] = visiting
+		stack = append(stack, n)
+		for _, next := range edges[n] {
+			switch state[next] {
+			case unvisited:
+				visit(next)
+			case visiting:
+				loop := slices.Clone(stack[slices.Index(stack, next):])
+				canonical := slices.Clone(loop)
+				sort.Strings(canonical)
+				if seen[strings.Join(canonical, "|")] {
+					continue
+				}
+				seen[strings.Join(canonical, "|")] = true
+				ids := []string{}
+				for i, from := range loop {
+					to := loop[(i+1)%len(loop)]
+					for _, id := range edgeIDs[from+"|"+to] {
+						if !slices.Contains(ids, id) {
+							ids = append(ids, id)
+						}
+					}
+				}
+				issues = append(issues, issue{
+					Check:     "oneWayLoops",
+					Severity:  severityWarning,
+					ObjectIDs: ids,
+					Message:   fmt.Sprintf("one-way synonyms loop: %s -> %s; use a regular synonym instead", strings.Join(loop, " -> "), loop[0]),
+				})
+			}
+		}
+		stack = stack[:len(stack)-1]
+		state[n] = visited
+	}
+	for _, n := range nodes {
+		if state[n] == unvisited {
+			visit(n)
+		}
+	}
+	return issues
+}
+
+// lintPlaceholders finds placeholders that match no record. A placeholder
+// is used when searching one of its replacements finds more records with
+// synonyms than without, the difference being records with the placeholder.
+func lintPlaceholders(client *search.Client, indexName string, synonyms []search.Synonym) ([]issue, error) {
+	placeholders := []search.Placeholder{}
+	requests := []query.Request{}
+	issues := []issue{}
+	for _, s := range synonyms {
+		p, ok := s.(search.Placeholder)
+		if !ok {
+			continue
+		}
+		if len(p.Replacements) == 0 {
+			issues = append(issues, issue{
+				Check:     "unusedPlaceholders",
+				Severity:  severityWarning,
+				ObjectIDs: []string{p.ObjectID()},
+				Message:   fmt.Sprintf("placeholder %q has no replacements, so no query matches it", p.Placeholder),
+			})
+			continue
+		}
+		placeholders = append(placeholders, p)
+		for _, synonymsEnabled := range []bool{true, false} {
+			requests = append(requests, query.Request{
+				IndexName: indexName,
+				Query:     p.Replacements[0],
+				Params: map[string]any{
+					"synonyms":       synonymsEnabled,
+					"hitsPerPage":    0,
+					"analytics":      false,
+					"clickAnalytics": false,
+					"enableABTest":   false,
+					"enableRules":    false,
+				},
+			})
+		}
+	}
+	if len(requests) == 0 {
+		return issues, nil
+	}
+
+	results, err := query.MultipleQueries(client, requests)
+	if err != nil {
+		return nil, err
+	}
+	for i, p := range placeholders {
+		with, without := results[2*i].NbHits, results[2*i+1].NbHits
+		if with > without {
+			continue
+		}
+		issues = append(issues, issue{
+			Check:     "unusedPlaceholders",
+			Severity:  severityWarning,
+			ObjectIDs: []string{p.ObjectID()},
+			Message:   fmt.Sprintf("placeholder %q doesn't seem to be in any record: searching %q finds %d records with or without synonyms", p.Placeholder, p.Replacements[0], with),
+		})
+	}
+	return issues, nil
+}
@@ -0,0 +1,170 @@
+package logs
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/opt"
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/search"
+	"github.com/algolia/mcp/pkg/mcputil"
+	"github.com/algolia/mcp/pkg/search/indexcache"
+)
+
+// maxLength is the largest number of log entries the API returns at once.
+const maxLength = 1000
+
+// logEntry is the representation of a log entry returned by the tool.
+type logEntry struct {
+	Timestamp        time.Time `json:"timestamp"`
+	Method           string    `json:"method"`
+	URL              string    `json:"url"`
+	AnswerCode       int       `json:"answerCode"`
+	Index            string    `json:"index,omitempty"`
+	InnerIndices     []string  `json:"innerIndices,omitempty"`
+	ProcessingTimeMS int64     `json:"processingTimeMs"`
+	NbAPICalls       int       `json:"nbApiCalls,omitempty"`
+	QueryNbHits      int       `json:"queryNbHits,omitempty"`
+	IP               string    `json:"ip"`
+	QueryBody        string    `json:"queryBody,omitempty"`
+	QueryHeaders     string    `json:"queryHeaders,omitempty"`
+	Answer           string    `json:"answer,omitempty"`
+}
+
+// apiKeyHeader matches the API key header in the logged request headers.
+var apiKeyHeader = regexp.MustCompile(`(?i)(x-algolia-api-key:\s*)(\S{4})\S*`)
+
+// apiKeyParam matches the API key in the query string of a logged URL, where
+// clients authenticating with URL parameters send it.
+var apiKeyParam = regexp.MustCompile(`(?i)(x-algolia-api-key=)([^&#\s]{4})[^&#\s]*`)
+
+func newLogEntry(l search.LogRes, includeAnswer bool) logEntry {
+	e := logEntry{
+		Timestamp:        l.Timestamp,
+		Method:           l.Method,
+		URL:              apiKeyParam.ReplaceAllString(l.URL, "${1}${2}****"),
+		AnswerCode:       l.AnswerCode,
+		Index:            l.Index,
+		ProcessingTimeMS: l.ProcessingTime.Milliseconds(),
+		NbAPICalls:       l.NbAPICalls,
+		QueryNbHits:      l.QueryNbHits,
+		IP:               l.IP,
+		QueryBody:        l.QueryBody,
+		QueryHeaders:     apiKeyHeader.ReplaceAllString(l.QueryHeaders, "${1}${2}****"),
+	}
+	for _, q := range l.InnerQueries {
+		e.InnerIndices = append(e.InnerIndices, q.IndexName)
+	}
+	if includeAnswer {
+		e.Answer = l.Answer
+	}
+	return e
+}
+
+// indices returns the indices a log entry targets.
+func (e logEntry) indices() []string {
+	if len(e.InnerIndices) > 0 {
+		return e.InnerIndices
+	}
+	if e.Index != "" {
+		return []string{e.Index}
+	}
+	return nil
+}
+
+func RegisterGetSearchLogs(mcps *server.MCPServer, cache *indexcache.Cache) {
+	getSearchLogsTool := mcp.NewTool(
+		"get_search_logs",
+		mcp.WithDescription("Get the most recent API calls received by the application, with the raw request and response. Logs are kept for 7 days and only the last 1000 entries are available. Requires an API key with the logs ACL"),
+		mcp.WithString(
+			"indexName",
+			mcp.Description("Only return logs of this index. When omitted, logs of every index are returned"),
+		),
+		mcp.WithString(
+			"type",
+			mcp.Description("The type of log entries to return (defaults to all)"),
+			mcp.Enum("all", "query", "build", "error"),
+		),
+		mcp.WithNumber(
+			"offset",
+			mcp.Description("The number of most recent entries to skip (defaults to 0)"),
+		),
+		mcp.WithNumber(
+			"length",
+			mcp.Description("The number of entries to return (defaults to 10, or 1000 when summarizing, max 1000)"),
+		),
+		mcp.WithBoolean(
+			"includeAnswer",
+			mcp.Description("Whether to include the response body of each call (defaults to false)"),
+		),
+		mcp.WithBoolean(
+			"summarize",
+			mcp.Description("Whether to return a summary of the entries, grouped by endpoint, status code, processing time and index, with slow queries and error bursts, instead of the entries themselves"),
+		),
+		mcp.WithNumber(
+			"slowThresholdMs",
+			mcp.Description("Processing time in milliseconds from which a query is reported as slow in the summary (defaults to 500)"),
+		),
+	)
+
+	mcps.AddTool(getSearchLogsTool, func(_ context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		opts := []any{}
+		if indexName, ok := req.Params.Arguments["indexName"].(string); ok && indexName != "" {
+			if err := cache.Allowed(indexName); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			opts = append(opts, opt.IndexName(indexName))
+		}
+		if logType, ok := req.Params.Arguments["type"].(string); ok && logType != "" {
+			opts = append(opts, opt.Type(logType))
+		}
+		if offset, ok := req.Params.Arguments["offset"].(float64); ok {
+			opts = append(opts, opt.Offset(int(offset)))
+		}
+		summarize, _ := req.Params.Arguments["summarize"].(bool)
+		if length, ok := req.Params.Arguments["length"].(float64); ok {
+			if length < 1 || length > maxLength {
+				return mcp.NewToolResultError(fmt.Sprintf("length must be between 1 and %d", maxLength)), nil
+			}
+			opts = append(opts, opt.Length(int(length)))
+		} else if summarize {
+			// A summary of the default 10 entries tells little.
+			opts = append(opts, opt.Length(maxLength))
+		}
+		includeAnswer, _ := req.Params.Arguments["includeAnswer"].(bool)
+
+		res, err := cache.Client().GetLogs(opts...)
+		if err != nil {
+			return nil, fmt.Errorf("could not get logs: %w", err)
+		}
+
+		// Leave out the entries of indices excluded by the allow and deny lists.
+		entries := make([]logEntry, 0, len(res.Logs))
+	logs:
+		for _, l := range res.Logs {
+			e := newLogEntry(l, includeAnswer)
+			for _, name := range e.indices() {
+				if cache.Allowed(name) != nil {
+					continue logs
+				}
+			}
+			entries = append(entries, e)
+		}
+
+		if summarize {
+			slowThreshold := defaultSlowThreshold
+			if ms, ok := req.Params.Arguments["slowThresholdMs"].(float64); ok && ms > 0 {
+				slowThreshold = time.Duration(ms) * time.Millisecond
+			}
+			summary := summarizeLogs(entries, slowThreshold)
+			summary.NbFetched = len(res.Logs)
+			return mcputil.JSONToolResult("Logs Summary", summary)
+		}
+
+		return mcputil.JSONToolResult("Logs", entries)
+	})
+}
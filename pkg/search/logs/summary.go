@@ -0,0 +1,272 @@
+package logs
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// defaultSlowThreshold is the processing time from which a query is slow.
+	defaultSlowThreshold = 500 * time.Millisecond
+	// errorBurstSize errors within errorBurstWindow make an error burst.
+	errorBurstSize   = 3
+	errorBurstWindow = time.Minute
+	// maxSlowQueries is the number of slow queries listed in a summary.
+	maxSlowQueries = 10
+)
+
+// processingTimeBuckets are the upper bounds of the processing time groups.
+var processingTimeBuckets = []struct {
+	label string
+	max   time.Duration
+}{
+	{"0-10ms", 10 * time.Millisecond},
+	{"10-100ms", 100 * time.Millisecond},
+	{"100-500ms", 500 * time.Millisecond},
+	{"500ms-1s", time.Second},
+	{"1s+", 0},
+}
+
+// pathKeywords are the fixed segments of API paths. Other segments after the
+// index name are identifiers (objectIDs, task IDs...) and are replaced by a
+// placeholder when grouping by endpoint.
+var pathKeywords = map[string]bool{
+	"*": true, "batch": true, "browse": true, "clear": true, "deleteByQuery": true,
+	"facets": true, "objects": true, "operation": true, "partial": true,
+	"queries": true, "query": true, "rules": true, "search": true,
+	"settings": true, "synonyms": true, "task": true,
+}
+
+// summary is the result of summarizeLogs.
+type summary struct {
+	// NbEntries is the number of entries the summary covers, and NbFetched
+	// the number fetched, entries of excluded indices included.
+	NbEntries        int          `json:"nbEntries"`
+	NbFetched        int          `json:"nbFetched"`
+	From             *time.Time   `json:"from,omitempty"`
+	To               *time.Time   `json:"to,omitempty"`
+	ByEndpoint       []group      `json:"byEndpoint"`
+	ByStatusCode     []group      `json:"byStatusCode"`
+	ByProcessingTime []group      `json:"byProcessingTime"`
+	ByIndex          []group      `json:"byIndex"`
+	SlowThresholdMS  int64        `json:"slowThresholdMs"`
+	NbSlowQueries    int          `json:"nbSlowQueries"`
+	SlowQueries      []slowQuery  `json:"slowQueries"`
+	ErrorBursts      []errorBurst `json:"errorBursts"`
+	Flags            []string     `json:"flags"`
+}
+
+type group struct {
+	Key                   string `json:"key"`
+	Count                 int    `json:"count"`
+	Errors                int    `json:"errors,omitempty"`
+	AvgProcessingTimeMS   int64  `json:"avgProcessingTimeMs"`
+	MaxProcessingTimeMS   int64  `json:"maxProcessingTimeMs"`
+	totalProcessingTimeMS int64
+}
+
+type slowQuery struct {
+	Timestamp        time.Time `json:"timestamp"`
+	Index            string    `json:"index,omitempty"`
+	ProcessingTimeMS int64     `json:"processingTimeMs"`
+	URL              string    `json:"url"`
+	QueryBody        string    `json:"queryBody,omitempty"`
+}
+
+type errorBurst struct {
+	From        time.Time      `json:"from"`
+	To          time.Time      `json:"to"`
+	Count       int            `json:"count"`
+	StatusCodes map[string]int `json:"statusCodes"`
+	Endpoints   []string       `json:"endpoints"`
+}
+
+// summarizeLogs groups entries and flags slow queries and error bursts.
+func summarizeLogs(entries []logEntry, slowThreshold time.Duration) summary {
+	s := summary{
+		NbEntries:       len(entries),
+		SlowThresholdMS: slowThreshold.Milliseconds(),
+		SlowQueries:     []slowQuery{},
+		ErrorBursts:     []errorBurst{},
+		Flags:           []string{},
+	}
+
+	byEndpoint := map[string]*group{}
+	byStatusCode := map[string]*group{}
+	byProcessingTime := map[string]*group{}
+	byIndex := map[string]*group{}
+	var errs []logEntry
+
+	for _, e := range entries {
+		if s.From == nil || e.Timestamp.Before(*s.From) {
+			t := e.Timestamp
+			s.From = &t
+		}
+		if s.To == nil || e.Timestamp.After(*s.To) {
+			t := e.Timestamp
+			s.To = &t
+		}
+
+		add(byEndpoint, e.Method+" "+endpoint(e.URL), e)
+		add(byStatusCode, strconv.Itoa(e.AnswerCode), e)
+		add(byProcessingTime, processingTimeBucket(time.Duration(e.ProcessingTimeMS)*time.Millisecond), e)
+		indices := e.indices()
+		if len(indices) == 0 {
+			indices = []string{"(none)"}
+		}
+		for _, name := range indices {
+			add(byIndex, name, e)
+		}
+
+		if time.Duration(e.ProcessingTimeMS)*time.Millisecond >= slowThreshold {
+			s.NbSlowQueries++
+			s.SlowQueries = append(s.SlowQueries, slowQuery{
+				Timestamp:        e.Timestamp,
+				Index:            strings.Join(indices, ","),
+				ProcessingTimeMS: e.ProcessingTimeMS,
+				URL:              e.URL,
+				QueryBody:        e.QueryBody,
+			})
+		}
+		if isError(e) {
+			errs = append(errs, e)
+		}
+	}
+
+	sort.SliceStable(s.SlowQueries, func(i, j int) bool {
+		return s.SlowQueries[i].ProcessingTimeMS > s.SlowQueries[j].ProcessingTimeMS
+	})
+	if len(s.SlowQueries) > maxSlowQueries {
+		s.SlowQueries = s.SlowQueries[:maxSlowQueries]
+	}
+
+	s.ByEndpoint = sortedGroups(byEndpoint)
+	s.ByStatusCode = sortedGroups(byStatusCode)
+	s.ByIndex = sortedGroups(byIndex)
+	for _, b := range processingTimeBuckets {
+		if g, ok := byProcessingTime[b.label]; ok {
+			s.ByProcessingTime = append(s.ByProcessingTime, finish(g))
+		}
+	}
+	s.ErrorBursts = errorBursts(errs)
+
+	if s.NbSlowQueries > 0 {
+		s.Flags = append(s.Flags, fmt.Sprintf("%d slow queries (>= %s)", s.NbSlowQueries, slowThreshold))
+	}
+	if len(errs) > 0 {
+		s.Flags = append(s.Flags, fmt.Sprintf("%d failed calls", len(errs)))
+	}
+	if len(s.ErrorBursts) > 0 {
+		s.Flags = append(s.Flags, fmt.Sprintf("%d error bursts (%d+ errors within %s)", len(s.ErrorBursts), errorBurstSize, errorBurstWindow))
+	}
+
+	return s
+}
+
+func isError(e logEntry) bool {
+	return e.AnswerCode >= 400
+}
+
+// endpoint returns the path of a logged URL with the index name and
+// identifiers replaced by placeholders, e.g. /1/indexes/{indexName}/query.
+func endpoint(rawURL string) string {
+	p := rawURL
+	if u, err := url.Parse(rawURL); err == nil {
+		p = u.Path
+	}
+	segments := strings.Split(strings.Trim(p, "/"), "/")
+	for i := range segments {
+		switch {
+		case i == 2 && segments[1] == "indexes" && segments[i] != "*":
+			segments[i] = "{indexName}"
+		case i > 2 && segments[1] == "indexes" && !pathKeywords[segments[i]]:
+			segments[i] = "{id}"
+		}
+	}
+	return "/" + strings.Join(segments, "/")
+}
+
+func processingTimeBucket(d time.Duration) string {
+	for _, b := range processingTimeBuckets {
+		if b.max == 0 || d < b.max {
+			return b.label
+		}
+	}
+	return processingTimeBuckets[len(processingTimeBuckets)-1].label
+}
+
+func add(groups map[string]*group, key string, e logEntry) {
+	g, ok := groups[key]
+	if !ok {
+		g = &group{Key: key}
+		groups[key] = g
+	}
+	g.Count++
+	if isError(e) {
+		g.Errors++
+	}
+	g.totalProcessingTimeMS += e.ProcessingTimeMS
+	if e.ProcessingTimeMS > g.MaxProcessingTimeMS {
+		g.MaxProcessingTimeMS = e.ProcessingTimeMS
+	}
+}
+
+func finish(g *group) group {
+	if g.Count > 0 {
+		g.AvgProcessingTimeMS = g.totalProcessingTimeMS / int64(g.Count)
+	}
+	return *g
+}
+
+// sortedGroups returns the groups by decreasing count.
+func sortedGroups(groups map[string]*group) []group {
+	sorted := make([]group, 0, len(groups))
+	for _, g := range groups {
+		sorted = append(sorted, finish(g))
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].Count != sorted[j].Count {
+			return sorted[i].Count > sorted[j].Count
+		}
+		return sorted[i].Key < sorted[j].Key
+	})
+	return sorted
+}
+
+// errorBursts finds the runs of failed calls where at least errorBurstSize
+// errors happen within errorBurstWindow of each other.
+func errorBursts(errs []logEntry) []errorBurst {
+	sort.Slice(errs, func(i, j int) bool { return errs[i].Timestamp.Before(errs[j].Timestamp) })
+
+	bursts := []errorBurst{}
+	for start := 0; start < len(errs); {
+		end := start + 1
+		for end < len(errs) && errs[end].Timestamp.Sub(errs[end-1].Timestamp) <= errorBurstWindow {
+			end++
+		}
+		if end-start >= errorBurstSize {
+			b := errorBurst{
+				From:        errs[start].Timestamp,
+				To:          errs[end-1].Timestamp,
+				Count:       end - start,
+				StatusCodes: map[string]int{},
+			}
+			endpoints := map[string]bool{}
+			for _, e := range errs[start:end] {
+				b.StatusCodes[strconv.Itoa(e.AnswerCode)]++
+				ep := e.Method + " " + endpoint(e.URL)
+				if !endpoints[ep] {
+					endpoints[ep] = true
+					b.Endpoints = append(b.Endpoints, ep)
+				}
+			}
+			bursts = append(bursts, b)
+		}
+		start = end
+	}
+	return bursts
+}
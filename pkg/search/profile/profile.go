@@ -0,0 +1,442 @@
+// Package profile infers the schema of the records of an index: attributes,
+// types, fill rates, cardinality and sizes, and checks the settings of the
+// index against it.
+package profile
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/opt"
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/search"
+)
+
+const (
+	// DefaultSampleSize is the number of records profiled by default.
+	DefaultSampleSize = 1000
+	// maxTrackedValues is the number of distinct values tracked per
+	// attribute; cardinality is capped beyond it.
+	maxTrackedValues = 10000
+	// nbTopValues is the number of most frequent values reported.
+	nbTopValues = 5
+	// nbLargestRecords is the number of largest records reported.
+	nbLargestRecords = 10
+)
+
+// Types of attribute values.
+const (
+	TypeString  = "string"
+	TypeInteger = "integer"
+	TypeNumber  = "number"
+	TypeBoolean = "boolean"
+	TypeObject  = "object"
+	TypeArray   = "array"
+	TypeNull    = "null"
+)
+
+// Profile is the inferred schema of the records of an index.
+type Profile struct {
+	IndexName string `json:"indexName"`
+	// NbRecords is the number of records in the index.
+	NbRecords int `json:"nbRecords"`
+	// NbProfiled is the number of records the profile is built from.
+	NbProfiled     int          `json:"nbProfiled"`
+	AvgRecordSize  int          `json:"avgRecordSize"`
+	MaxRecordSize  int          `json:"maxRecordSize"`
+	LargestRecords []RecordSize `json:"largestRecords"`
+	// Attributes are sorted by path. Nested attributes use dotted paths,
+	// and values inside arrays are profiled under the path of the array.
+	Attributes []*Attribute `json:"attributes"`
+
+	byPath map[string]*Attribute
+}
+
+// RecordSize is the size of a record, in bytes of JSON.
+type RecordSize struct {
+	ObjectID string `json:"objectID"`
+	Size     int    `json:"size"`
+}
+
+// Attribute is the profile of an attribute.
+type Attribute struct {
+	Path string `json:"path"`
+	// Depth is the number of parents of a nested attribute.
+	Depth int `json:"depth"`
+	// Types counts the values of each type. Values inside arrays are
+	// counted in ItemTypes instead.
+	Types     map[string]int `json:"types,omitempty"`
+	ItemTypes map[string]int `json:"itemTypes,omitempty"`
+	// Count is the number of records having the attribute.
+	Count    int     `json:"count"`
+	FillRate float64 `json:"fillRate"`
+	// Cardinality is the number of distinct scalar values, capped at
+	// maxTrackedValues.
+	Cardinality       int          `json:"cardinality"`
+	CardinalityCapped bool         `json:"cardinalityCapped,omitempty"`
+	TopValues         []ValueCount `json:"topValues,omitempty"`
+	MinArrayLength    *int         `json:"minArrayLength,omitempty"`
+	MaxArrayLength    *int         `json:"maxArrayLength,omitempty"`
+	AvgArrayLength    *float64     `json:"avgArrayLength,omitempty"`
+	Min               *float64     `json:"min,omitempty"`
+	Max               *float64     `json:"max,omitempty"`
+	AvgStringLength   *float64     `json:"avgStringLength,omitempty"`
+
+	values       map[string]int
+	nbArrays     int
+	arrayLengths int
+	nbStrings    int
+	stringLength int
+	lastRecord   int
+}
+
+// ValueCount is a value and the number of times it was seen.
+type ValueCount struct {
+	Value string `json:"value"`
+	Count int    `json:"count"`
+}
+
+// Attribute returns the profile of an attribute, or nil if no profiled
+// record has it.
+func (p *Profile) Attribute(path string) *Attribute {
+	return p.byPath[path]
+}
+
+// Is tells whether some values of the attribute, or of its array items,
+// have one of the given types.
+func (a *Attribute) Is(types ...string) bool {
+	for _, t := range types {
+		if a.Types[t] > 0 || a.ItemTypes[t] > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// Only tells whether all the non-null values of the attribute, and of its
+// array items, have one of the given types.
+func (a *Attribute) Only(types ...string) bool {
+	allowed := map[string]bool{TypeNull: true, TypeArray: true}
+	for _, t := range types {
+		allowed[t] = true
+	}
+	for t, n := range a.Types {
+		if n > 0 && !allowed[t] {
+			return false
+		}
+	}
+	for t, n := range a.ItemTypes {
+		if n > 0 && !allowed[t] {
+			return false
+		}
+	}
+	return true
+}
+
+// Build profiles up to sampleSize records of the index, or all of them when
+// sampleSize is 0. visit, when not nil, is called with every profiled record.
+func Build(index *search.Index, sampleSize int, visit func(record map[string]any)) (*Profile, error) {
+	res, err := index.Search("", opt.HitsPerPage(0), opt.Analytics(false))
+	if err != nil {
+		return nil, fmt.Errorf("could not count records: %w", err)
+	}
+	p := &Profile{
+		IndexName:      index.GetName(),
+		NbRecords:      res.NbHits,
+		LargestRecords: []RecordSize{},
+		byPath:         map[string]*Attribute{},
+	}
+
+	it, err := index.BrowseObjects()
+	if err != nil {
+		return nil, fmt.Errorf("could not browse records: %w", err)
+	}
+	totalSize := 0
+	for sampleSize == 0 || p.NbProfiled < sampleSize {
+		record, err := it.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("could not browse records: %w", err)
+		}
+		m, ok := record.(map[string]any)
+		if !ok {
+			continue
+		}
+		p.NbProfiled++
+		if visit != nil {
+			visit(m)
+		}
+
+		b, err := json.Marshal(m)
+		if err != nil {
+			return nil, fmt.Errorf("could not encode record: %w", err)
+		}
+		totalSize += len(b)
+		p.MaxRecordSize = max(p.MaxRecordSize, len(b))
+		objectID, _ := m["objectID"].(string)
+		p.addLargest(RecordSize{ObjectID: objectID, Size: len(b)})
+
+		for k, v := range m {
+			if k == "objectID" {
+				continue
+			}
+			p.add(k, 0, v, false)
+		}
+	}
+	if p.NbProfiled > 0 {
+		p.AvgRecordSize = totalSize / p.NbProfiled
+	}
+	p.finish()
+	return p, nil
+}
+
+func (p *Profile) addLargest(r RecordSize) {
+	i := sort.Search(len(p.LargestRecords), func(i int) bool { return p.LargestRecords[i].Size < r.Size })
+	if i >= nbLargestRecords {
+		return
+	}
+	p.LargestRecords = append(p.LargestRecords[:i], append([]RecordSize{r}, p.LargestRecords[i:]...)...)
+	if len(p.LargestRecords) > nbLargestRecords {
+		p.LargestRecords = p.LargestRecords[:nbLargestRecords]
+	}
+}
+
+// add records a value of the attribute at path. inArray is set for values
+// inside an array, whose type is counted as an item type.
+func (p *Profile) add(path string, depth int, v any, inArray bool) {
+	a, ok := p.byPath[path]
+	if !ok {
+		a = &Attribute{Path: path, Depth: depth, Types: map[string]int{}, values: map[string]int{}}
+		p.byPath[path] = a
+	}
+	if a.lastRecord != p.NbProfiled {
+		a.lastRecord = p.NbProfiled
+		a.Count++
+	}
+
+	t := typeOf(v)
+	if inArray {
+		if a.ItemTypes == nil {
+			a.ItemTypes = map[string]int{}
+		}
+		a.ItemTypes[t]++
+	} else {
+		a.Types[t]++
+	}
+
+	switch v := v.(type) {
+	case map[string]any:
+		for k, child := range v {
+			p.add(path+"."+k, depth+1, child, inArray)
+		}
+	case []any:
+		a.nbArrays++
+		a.arrayLengths += len(v)
+		if a.MinArrayLength == nil || len(v) < *a.MinArrayLength {
+			a.MinArrayLength = ptr(len(v))
+		}
+		if a.MaxArrayLength == nil || len(v) > *a.MaxArrayLength {
+			a.MaxArrayLength = ptr(len(v))
+		}
+		// Attributes of objects in arrays are profiled under the path of
+		// the array, e.g. variants.color, as the engine refers to them.
+		for _, item := range v {
+			p.add(path, depth, item, true)
+		}
+	case float64:
+		if a.Min == nil || v < *a.Min {
+			a.Min = ptr(v)
+		}
+		if a.Max == nil || v > *a.Max {
+			a.Max = ptr(v)
+		}
+		a.addValue(fmt.Sprint(v))
+	case string:
+		a.nbStrings++
+		a.stringLength += len(v)
+		a.addValue(v)
+	case bool:
+		a.addValue(fmt.Sprint(v))
+	}
+}
+
+func (a *Attribute) addValue(v string) {
+	if _, ok := a.values[v]; !ok && len(a.values) >= maxTrackedValues {
+		a.CardinalityCapped = true
+		return
+	}
+	a.values[v]++
+}
+
+// finish computes the derived statistics and sorts the attributes.
+func (p *Profile) finish() {
+	for _, a := range p.byPath {
+		if p.NbProfiled > 0 {
+			a.FillRate = round(float64(a.Count) / float64(p.NbProfiled))
+		}
+		a.Cardinality = len(a.values)
+		if a.nbArrays > 0 {
+			a.AvgArrayLength = ptr(round(float64(a.arrayLengths) / float64(a.nbArrays)))
+		}
+		if a.nbStrings > 0 {
+			a.AvgStringLength = ptr(round(float64(a.stringLength) / float64(a.nbStrings)))
+		}
+		for v, n := range a.values {
+			a.TopValues = append(a.TopValues, ValueCount{Value: v, Count: n})
+		}
+		sort.Slice(a.TopValues, func(i, j int) bool {
+			if a.TopValues[i].Count != a.TopValues[j].Count {
+				return a.TopValues[i].Count > a.TopValues[j].Count
+			}
+			return a.TopValues[i].Value < a.TopValues[j].Value
+		})
+		// Top values only tell something when values repeat.
+		if len(a.TopValues) > 0 && a.TopValues[0].Count < 2 {
+			a.TopValues = nil
+		}
+		if len(a.TopValues) > nbTopValues {
+			a.TopValues = a.TopValues[:nbTopValues]
+		}
+		p.Attributes = append(p.Attributes, a)
+	}
+	sort.Slice(p.Attributes, func(i, j int) bool { return p.Attributes[i].Path < p.Attributes[j].Path })
+}
+
+func typeOf(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return TypeNull
+	case string:
+		return TypeString
+	case bool:
+		return TypeBoolean
+	case float64:
+		if v == math.Trunc(v) {
+			return TypeInteger
+		}
+		return TypeNumber
+	case []any:
+		return TypeArray
+	case map[string]any:
+		return TypeObject
+	default:
+		return fmt.Sprintf("%T", v)
+	}
+}
+
+func round(f float64) float64 {
+	return math.Round(f*1000) / 1000
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+// Issue is a setting that doesn't fit the records.
+type Issue struct {
+	Setting   string `json:"setting"`
+	Attribute string `json:"attribute"`
+	Severity  string `json:"severity"`
+	Message   string `json:"message"`
+}
+
+// CheckSettings finds the attributes referred to by the settings that are
+// missing from the profiled records or have the wrong type.
+func CheckSettings(p *Profile, settings search.Settings) []Issue {
+	issues := []Issue{}
+	missing := func(setting, attr string) Issue {
+		msg := fmt.Sprintf("attribute %q isn't in any of the %d records profiled", attr, p.NbProfiled)
+		if p.NbProfiled < p.NbRecords {
+			msg += fmt.Sprintf(" (out of %d), profile all records to be sure", p.NbRecords)
+		}
+		return Issue{Setting: setting, Attribute: attr, Severity: "warning", Message: msg}
+	}
+
+	for _, entry := range settings.SearchableAttributes.Get() {
+		for _, attr := range strings.Split(entry, ",") {
+			attr = unwrap(strings.TrimSpace(attr), "unordered", "ordered")
+			if attr == "" || attr == "*" {
+				continue
+			}
+			a := p.Attribute(attr)
+			switch {
+			case a == nil:
+				issues = append(issues, missing("searchableAttributes", attr))
+			case !a.Is(TypeString, TypeObject):
+				issues = append(issues, Issue{
+					Setting: "searchableAttributes", Attribute: attr, Severity: "info",
+					Message: fmt.Sprintf("attribute %q only has %s values, which searches match as text; filter on it instead", attr, typeList(a)),
+				})
+			}
+		}
+	}
+
+	for _, entry := range settings.AttributesForFaceting.Get() {
+		attr := unwrap(entry, "searchable", "filterOnly", "afterDistinct")
+		a := p.Attribute(attr)
+		switch {
+		case a == nil:
+			issues = append(issues, missing("attributesForFaceting", attr))
+		case a.Only(TypeObject):
+			issues = append(issues, Issue{
+				Setting: "attributesForFaceting", Attribute: attr, Severity: "error",
+				Message: fmt.Sprintf("attribute %q holds objects, which can't be faceted; facet on one of its nested attributes instead", attr),
+			})
+		}
+	}
+
+	for _, entry := range settings.CustomRanking.Get() {
+		attr := unwrap(entry, "asc", "desc")
+		a := p.Attribute(attr)
+		switch {
+		case a == nil:
+			issues = append(issues, missing("customRanking", attr))
+		case a.Types[TypeArray] > 0:
+			issues = append(issues, Issue{
+				Setting: "customRanking", Attribute: attr, Severity: "error",
+				Message: fmt.Sprintf("attribute %q holds arrays, which can't be used for ranking", attr),
+			})
+		case !a.Only(TypeInteger, TypeNumber, TypeBoolean):
+			issues = append(issues, Issue{
+				Setting: "customRanking", Attribute: attr, Severity: "warning",
+				Message: fmt.Sprintf("attribute %q has %s values, which rank alphabetically; store it as a number", attr, typeList(a)),
+			})
+		}
+	}
+
+	if attr := settings.AttributeForDistinct.Get(); attr != "" && p.Attribute(attr) == nil {
+		issues = append(issues, missing("attributeForDistinct", attr))
+	}
+	return issues
+}
+
+// unwrap removes the modifier around an attribute, e.g. desc(price).
+func unwrap(s string, modifiers ...string) string {
+	for _, m := range modifiers {
+		if strings.HasPrefix(s, m+"(") && strings.HasSuffix(s, ")") {
+			return s[len(m)+1 : len(s)-1]
+		}
+	}
+	return s
+}
+
+// typeList lists the non-null types of an attribute.
+func typeList(a *Attribute) string {
+	types := []string{}
+	for _, counts := range []map[string]int{a.Types, a.ItemTypes} {
+		for t, n := range counts {
+			if n > 0 && t != TypeNull && t != TypeArray && !slices.Contains(types, t) {
+				types = append(types, t)
+			}
+		}
+	}
+	sort.Strings(types)
+	return strings.Join(types, "/")
+}
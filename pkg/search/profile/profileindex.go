@@ -0,0 +1,63 @@
+package profile
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/algolia/mcp/pkg/mcputil"
+	"github.com/algolia/mcp/pkg/search/indexcache"
+)
+
+func RegisterProfileIndex(mcps *server.MCPServer, cache *indexcache.Cache) {
+	profileIndexTool := mcp.NewTool(
+		"profile_index",
+		mcp.WithDescription("Infer the schema of the records of an index from a sample or from all of them: attribute paths (nested ones included), types, fill rates, cardinality and most frequent values, array lengths, numeric ranges and the largest records. Then check the settings against it to find searchableAttributes, attributesForFaceting, customRanking and attributeForDistinct entries that refer to missing attributes or attributes of the wrong type"),
+		mcp.WithString(
+			"indexName",
+			mcp.Description(indexcache.IndexNameDescription),
+		),
+		mcp.WithNumber(
+			"sampleSize",
+			mcp.Description(fmt.Sprintf("The number of records to profile (default: %d)", DefaultSampleSize)),
+		),
+		mcp.WithBoolean(
+			"all",
+			mcp.Description("Profile every record of the index instead of a sample; slower on large indices"),
+		),
+	)
+
+	mcps.AddTool(profileIndexTool, func(_ context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		index, err := cache.FromRequest(req)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		sampleSize := DefaultSampleSize
+		if n, ok := req.Params.Arguments["sampleSize"].(float64); ok {
+			if n < 1 {
+				return mcp.NewToolResultError("sampleSize must be at least 1"), nil
+			}
+			sampleSize = int(n)
+		}
+		if all, _ := req.Params.Arguments["all"].(bool); all {
+			sampleSize = 0
+		}
+
+		p, err := Build(index, sampleSize, nil)
+		if err != nil {
+			return nil, err
+		}
+		settings, err := index.GetSettings()
+		if err != nil {
+			return nil, fmt.Errorf("could not get settings: %w", err)
+		}
+
+		return mcputil.JSONToolResult("index profile", map[string]any{
+			"profile":        p,
+			"settingsIssues": CheckSettings(p, settings),
+		})
+	})
+}
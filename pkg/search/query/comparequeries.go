@@ -0,0 +1,262 @@
+package query
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/algolia/mcp/pkg/mcputil"
+	"github.com/algolia/mcp/pkg/search/indexcache"
+)
+
+// rankChange is the move of a record present in both result lists.
+type rankChange struct {
+	ObjectID string `json:"objectID"`
+	Before   int    `json:"before"`
+	After    int    `json:"after"`
+	Delta    int    `json:"delta"`
+}
+
+// queryComparison compares the top k results of a query on two indices.
+// Positions are 1-based. Overlap is the share of the k positions filled by
+// records found in both, so it's below 1 when a query has fewer than k hits.
+type queryComparison struct {
+	Query       string       `json:"query"`
+	NbHitsA     int          `json:"nbHitsA"`
+	NbHitsB     int          `json:"nbHitsB"`
+	Identical   bool         `json:"identical"`
+	Overlap     float64      `json:"overlapAtK"`
+	KendallTau  *float64     `json:"kendallTau,omitempty"`
+	Gained      []string     `json:"gained"`
+	Lost        []string     `json:"lost"`
+	RankChanges []rankChange `json:"rankChanges"`
+	TopA        []string     `json:"topA"`
+	TopB        []string     `json:"topB"`
+}
+
+func compareRankings(q string, a, b []string, k int) queryComparison {
+	c := queryComparison{
+		Query:       q,
+		Identical:   strings.Join(a, "\x00") == strings.Join(b, "\x00"),
+		Gained:      []string{},
+		Lost:        []string{},
+		RankChanges: []rankChange{},
+		TopA:        a,
+		TopB:        b,
+	}
+
+	posA := positions(a)
+	posB := positions(b)
+	common := []string{}
+	for _, id := range a {
+		if _, ok := posB[id]; ok {
+			common = append(common, id)
+		} else {
+			c.Lost = append(c.Lost, id)
+		}
+	}
+	for _, id := range b {
+		if _, ok := posA[id]; !ok {
+			c.Gained = append(c.Gained, id)
+		}
+	}
+
+	c.Overlap = float64(len(common)) / float64(k)
+
+	for _, id := range common {
+		if d := posB[id] - posA[id]; d != 0 {
+			c.RankChanges = append(c.RankChanges, rankChange{ObjectID: id, Before: posA[id], After: posB[id], Delta: d})
+		}
+	}
+	sort.SliceStable(c.RankChanges, func(i, j int) bool {
+		return abs(c.RankChanges[i].Delta) > abs(c.RankChanges[j].Delta)
+	})
+
+	c.KendallTau = kendallTau(common, posA, posB)
+	return c
+}
+
+// kendallTau returns the rank correlation of the records present in both
+// lists, from 1 (same order) to -1 (reversed), or nil with fewer than two.
+func kendallTau(common []string, posA, posB map[string]int) *float64 {
+	if len(common) < 2 {
+		return nil
+	}
+	var concordant, discordant int
+	for i := 0; i < len(common); i++ {
+		for j := i + 1; j < len(common); j++ {
+			x, y := common[i], common[j]
+			if (posA[x]-posA[y])*(posB[x]-posB[y]) > 0 {
+				concordant++
+			} else {
+				discordant++
+			}
+		}
+	}
+	tau := float64(concordant-discordant) / float64(concordant+discordant)
+	return &tau
+}
+
+func positions(ids []string) map[string]int {
+	pos := make(map[string]int, len(ids))
+	for i, id := range ids {
+		if _, ok := pos[id]; !ok {
+			pos[id] = i + 1
+		}
+	}
+	return pos
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+func RegisterCompareQueries(mcps *server.MCPServer, cache *indexcache.Cache) {
+	compareQueriesTool := mcp.NewTool(
+		"compare_queries",
+		mcp.WithDescription("Run the same queries against two indices (e.g. production and a replica with new ranking) and compare their top k results: rank changes, records gained and lost, overlap@k and Kendall tau per query"),
+		mcp.WithArray(
+			"queries",
+			mcp.Description("The queries to compare"),
+			mcp.Items(map[string]any{"type": "string"}),
+			mcp.Required(),
+		),
+		mcp.WithString(
+			"indexName",
+			mcp.Description("The first index, A (defaults to ALGOLIA_INDEX_NAME)"),
+		),
+		mcp.WithString(
+			"otherIndexName",
+			mcp.Description("The second index, B, compared with the first"),
+			mcp.Required(),
+		),
+		mcp.WithNumber(
+			"k",
+			mcp.Description("The number of top hits to compare (defaults to 10)"),
+		),
+		mcp.WithString(
+			"filters",
+			mcp.Description("The filter expression applied to every query on both indices"),
+		),
+		mcp.WithString(
+			"params",
+			mcp.Description(`Search parameters applied to every query on both indices, as JSON (e.g. {"ruleContexts": ["mobile"]})`),
+		),
+	)
+
+	mcps.AddTool(compareQueriesTool, func(_ context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		indexA, err := cache.FromRequest(req)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		otherName, _ := req.Params.Arguments["otherIndexName"].(string)
+		if otherName == "" {
+			return mcp.NewToolResultError("otherIndexName parameter is required"), nil
+		}
+		indexB, err := cache.Index(otherName)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		var queries []string
+		switch v := req.Params.Arguments["queries"].(type) {
+		case []any:
+			for _, item := range v {
+				q, ok := item.(string)
+				if !ok {
+					return mcp.NewToolResultError("invalid queries, expected an array of strings"), nil
+				}
+				queries = append(queries, q)
+			}
+		case string:
+			queries = strings.Split(v, "\n")
+		}
+		if len(queries) == 0 {
+			return mcp.NewToolResultError("queries parameter is required"), nil
+		}
+
+		k := 10
+		if v, ok := req.Params.Arguments["k"].(float64); ok && v > 0 {
+			k = int(v)
+		}
+
+		params := map[string]any{}
+		if raw, ok := req.Params.Arguments["params"].(string); ok && raw != "" {
+			if err := json.Unmarshal([]byte(raw), &params); err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("invalid params JSON: %v", err)), nil
+			}
+		}
+		// Keep analytics and A/B tests out of the comparison.
+		params["analytics"] = false
+		params["clickAnalytics"] = false
+		params["enableABTest"] = false
+		if filters, ok := req.Params.Arguments["filters"].(string); ok && filters != "" {
+			params["filters"] = filters
+		}
+		params["hitsPerPage"] = k
+		params["page"] = 0
+		params["attributesToRetrieve"] = []string{"objectID"}
+		params["attributesToHighlight"] = []string{}
+		params["attributesToSnippet"] = []string{}
+
+		requests := make([]Request, 0, 2*len(queries))
+		for _, q := range queries {
+			requests = append(requests,
+				Request{IndexName: indexA.GetName(), Query: q, Params: params},
+				Request{IndexName: indexB.GetName(), Query: q, Params: params},
+			)
+		}
+		results, err := MultipleQueries(cache.Client(), requests)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		comparisons := make([]queryComparison, len(queries))
+		var sumOverlap, sumTau float64
+		nbTau, nbIdentical := 0, 0
+		for i, q := range queries {
+			a, b := results[2*i], results[2*i+1]
+			c := compareRankings(q, a.ObjectIDs(), b.ObjectIDs(), k)
+			c.NbHitsA, c.NbHitsB = a.NbHits, b.NbHits
+			comparisons[i] = c
+			sumOverlap += c.Overlap
+			if c.KendallTau != nil {
+				sumTau += *c.KendallTau
+				nbTau++
+			}
+			if c.Identical {
+				nbIdentical++
+			}
+		}
+
+		summary := map[string]any{
+			"indexA":         indexA.GetName(),
+			"indexB":         indexB.GetName(),
+			"k":              k,
+			"nbQueries":      len(queries),
+			"nbIdentical":    nbIdentical,
+			"meanOverlapAtK": sumOverlap / float64(len(queries)),
+			"meanKendallTau": nil,
+		}
+		if nbTau > 0 {
+			summary["meanKendallTau"] = sumTau / float64(nbTau)
+		}
+		// The queries whose results changed the most come first.
+		sort.SliceStable(comparisons, func(i, j int) bool {
+			return comparisons[i].Overlap < comparisons[j].Overlap
+		})
+
+		return mcputil.JSONToolResult("query comparison", map[string]any{
+			"summary": summary,
+			"queries": comparisons,
+		})
+	})
+}
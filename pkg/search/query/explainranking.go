@@ -0,0 +1,390 @@
+package query
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/opt"
+	"github.com/algolia/mcp/pkg/mcputil"
+	"github.com/algolia/mcp/pkg/search/indexcache"
+)
+
+// defaultRanking is the ranking formula of indices that don't set one.
+var defaultRanking = []string{"typo", "geo", "words", "filters", "proximity", "attribute", "exact", "custom"}
+
+const (
+	// explainHitsPerPage is the page size used to locate records.
+	explainHitsPerPage = 100
+	// maxExplainHits is the default pagination limit of an index: records
+	// ranked lower can't be retrieved by a search.
+	maxExplainHits = 1000
+)
+
+// highlightedWord matches the highlighted parts of a highlight result.
+var highlightedWord = regexp.MustCompile(`<em>(.*?)</em>`)
+
+// criterionValue is the value of a ranking criterion for a record, with
+// whether a lower value ranks higher.
+type criterionValue struct {
+	Value       any  `json:"value"`
+	LowerIsBest bool `json:"-"`
+}
+
+// explainedRecord is the ranking breakdown of a record.
+type explainedRecord struct {
+	ObjectID           string                    `json:"objectID"`
+	Found              bool                      `json:"found"`
+	Position           int                       `json:"position,omitempty"`
+	Criteria           map[string]criterionValue `json:"criteria,omitempty"`
+	Promoted           bool                      `json:"promoted,omitempty"`
+	RankingInfo        map[string]any            `json:"rankingInfo,omitempty"`
+	AlternativeMatches []string                  `json:"alternativeMatches,omitempty"`
+}
+
+// rankingComparison explains why a record ranks above the next one.
+type rankingComparison struct {
+	Higher    string `json:"higher"`
+	Lower     string `json:"lower"`
+	DecidedBy string `json:"decidedBy"`
+	Detail    string `json:"detail"`
+}
+
+func RegisterExplainRanking(mcps *server.MCPServer, cache *indexcache.Cache) {
+	explainRankingTool := mcp.NewTool(
+		"explain_ranking",
+		mcp.WithDescription("Explain why records rank where they do for a query. Locates the given objectIDs in the results, even beyond the first page, breaks down each ranking criterion (typo, geo, words, filters, proximity, attribute, exact, custom) side by side, tells which criterion decides between consecutive records, and lists the rules applied and the synonyms and other alternatives matched"),
+		mcp.WithString(
+			"indexName",
+			mcp.Description(indexcache.IndexNameDescription),
+		),
+		mcp.WithString(
+			"query",
+			mcp.Description("The query to explain"),
+			mcp.Required(),
+		),
+		mcp.WithString(
+			"objectIDs",
+			mcp.Description("Comma-separated list of the objectIDs of the records to explain"),
+			mcp.Required(),
+		),
+		mcp.WithString(
+			"filters",
+			mcp.Description("The filter expression of the search (e.g., 'category:Book AND price < 100')"),
+		),
+		mcp.WithString(
+			"params",
+			mcp.Description(`Other search parameters of the search, as JSON (e.g. {"ruleContexts": ["mobile"], "aroundLatLng": "48.85,2.35"})`),
+		),
+	)
+
+	mcps.AddTool(explainRankingTool, func(_ context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		index, err := cache.FromRequest(req)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		q, _ := req.Params.Arguments["query"].(string)
+
+		var objectIDs []string
+		objectIDsStr, _ := req.Params.Arguments["objectIDs"].(string)
+		for _, id := range strings.Split(objectIDsStr, ",") {
+			if id = strings.TrimSpace(id); id != "" {
+				objectIDs = append(objectIDs, id)
+			}
+		}
+		if len(objectIDs) == 0 {
+			return mcp.NewToolResultError("objectIDs parameter is required"), nil
+		}
+
+		params := map[string]any{}
+		if raw, ok := req.Params.Arguments["params"].(string); ok && raw != "" {
+			if err := json.Unmarshal([]byte(raw), &params); err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("invalid params JSON: %v", err)), nil
+			}
+		}
+		if filters, ok := req.Params.Arguments["filters"].(string); ok && filters != "" {
+			params["filters"] = filters
+		}
+		params["getRankingInfo"] = true
+		params["explain"] = []string{"match.alternatives"}
+		params["analytics"] = false
+		params["clickAnalytics"] = false
+		params["hitsPerPage"] = explainHitsPerPage
+		params["attributesToRetrieve"] = []string{"objectID"}
+		params["attributesToSnippet"] = []string{}
+
+		settings, err := index.GetSettings()
+		if err != nil {
+			return nil, fmt.Errorf("could not get settings: %w", err)
+		}
+		ranking := settings.Ranking.Get()
+		if len(ranking) == 0 {
+			ranking = defaultRanking
+		}
+		customRanking := settings.CustomRanking.Get()
+		searchableAttributes := settings.SearchableAttributes.Get()
+
+		// Page through the results until every record is found.
+		records := make(map[string]*explainedRecord, len(objectIDs))
+		for _, id := range objectIDs {
+			records[id] = &explainedRecord{ObjectID: id}
+		}
+		var first Result
+		nbFound, searched := 0, 0
+		for page := 0; nbFound < len(objectIDs) && searched < maxExplainHits; page++ {
+			params["page"] = page
+			results, err := MultipleQueries(cache.Client(), []Request{{IndexName: index.GetName(), Query: q, Params: params}})
+			if err != nil {
+				return nil, err
+			}
+			res := results[0]
+			if page == 0 {
+				first = res
+			}
+			for i, hit := range res.Hits {
+				id, _ := hit["objectID"].(string)
+				r, ok := records[id]
+				if !ok || r.Found {
+					continue
+				}
+				r.Found = true
+				r.Position = searched + i + 1
+				r.RankingInfo, _ = hit["_rankingInfo"].(map[string]any)
+				r.Promoted, _ = r.RankingInfo["promoted"].(bool)
+				r.AlternativeMatches = alternativeMatches(q, hit["_highlightResult"])
+				nbFound++
+			}
+			searched += len(res.Hits)
+			if len(res.Hits) < explainHitsPerPage {
+				break
+			}
+		}
+
+		// Custom ranking values are read from the records themselves.
+		customValues := map[string]map[string]any{}
+		if len(customRanking) > 0 {
+			attrs := make([]string, 0, len(customRanking))
+			for _, c := range customRanking {
+				attrs = append(attrs, rankingAttribute(c))
+			}
+			var objects []map[string]any
+			if err := index.GetObjects(objectIDs, &objects, opt.AttributesToRetrieve(attrs...)); err != nil {
+				return nil, fmt.Errorf("could not get records: %w", err)
+			}
+			for _, o := range objects {
+				if id, ok := o["objectID"].(string); ok {
+					customValues[id] = o
+				}
+			}
+		}
+
+		ordered := []*explainedRecord{}
+		notFound := []string{}
+		for _, id := range objectIDs {
+			r := records[id]
+			if !r.Found {
+				notFound = append(notFound, id)
+				continue
+			}
+			r.Criteria = criteria(r.RankingInfo, ranking, customRanking, customValues[id], searchableAttributes)
+			ordered = append(ordered, r)
+		}
+		sort.Slice(ordered, func(i, j int) bool { return ordered[i].Position < ordered[j].Position })
+
+		comparisons := []rankingComparison{}
+		for i := 0; i+1 < len(ordered); i++ {
+			comparisons = append(comparisons, compareRecords(ordered[i], ordered[i+1], ranking, customRanking))
+		}
+
+		result := map[string]any{
+			"indexName":       index.GetName(),
+			"query":           q,
+			"nbHits":          first.NbHits,
+			"searchedHits":    searched,
+			"ranking":         ranking,
+			"customRanking":   customRanking,
+			"records":         ordered,
+			"comparisons":     comparisons,
+			"appliedRules":    first.AppliedRules,
+			"matchedSynonyms": matchedAlternatives(first.Explain, "synonym"),
+			"explain":         first.Explain,
+		}
+		if len(notFound) > 0 {
+			result["notFound"] = notFound
+			result["notFoundReason"] = fmt.Sprintf("not in the first %d results, or not matching the query and filters", searched)
+		}
+
+		return mcputil.JSONToolResult("ranking explanation", result)
+	})
+}
+
+// criteria maps each criterion of the ranking formula to its value for a
+// record.
+func criteria(info map[string]any, ranking, customRanking []string, record map[string]any, searchableAttributes []string) map[string]criterionValue {
+	c := map[string]criterionValue{}
+	for _, criterion := range ranking {
+		switch criterion {
+		case "typo":
+			c["typo"] = criterionValue{Value: info["nbTypos"], LowerIsBest: true}
+		case "geo":
+			if v, ok := info["geoDistance"]; ok {
+				c["geo"] = criterionValue{Value: v, LowerIsBest: true}
+			}
+		case "words":
+			c["words"] = criterionValue{Value: info["words"]}
+		case "filters":
+			c["filters"] = criterionValue{Value: info["filters"]}
+		case "proximity":
+			c["proximity"] = criterionValue{Value: info["proximityDistance"], LowerIsBest: true}
+		case "attribute":
+			// firstMatchedWord is the index of the best matching searchable
+			// attribute times 1000 plus the position of the word in it.
+			fmw, _ := info["firstMatchedWord"].(float64)
+			attr := map[string]any{"firstMatchedWord": fmw, "attributeIndex": int(fmw) / 1000, "wordPosition": int(fmw) % 1000}
+			if i := int(fmw) / 1000; i < len(searchableAttributes) {
+				attr["attribute"] = searchableAttributes[i]
+			}
+			c["attribute"] = criterionValue{Value: attr, LowerIsBest: true}
+		case "exact":
+			c["exact"] = criterionValue{Value: info["nbExactWords"]}
+		case "custom":
+			for _, cr := range customRanking {
+				c[cr] = criterionValue{Value: lookup(record, rankingAttribute(cr)), LowerIsBest: strings.HasPrefix(cr, "asc(")}
+			}
+		default:
+			// asc(attribute) or desc(attribute) criteria of sorting replicas.
+			c[criterion] = criterionValue{Value: lookup(record, rankingAttribute(criterion)), LowerIsBest: strings.HasPrefix(criterion, "asc(")}
+		}
+	}
+	return c
+}
+
+// compareRecords finds the first criterion of the ranking formula on which
+// two records differ.
+func compareRecords(higher, lower *explainedRecord, ranking, customRanking []string) rankingComparison {
+	c := rankingComparison{Higher: higher.ObjectID, Lower: lower.ObjectID}
+	if higher.Promoted && !lower.Promoted {
+		c.DecidedBy = "promoted"
+		c.Detail = fmt.Sprintf("%s is promoted by a rule", higher.ObjectID)
+		return c
+	}
+
+	keys := []string{}
+	for _, criterion := range ranking {
+		if criterion == "custom" {
+			keys = append(keys, customRanking...)
+		} else {
+			keys = append(keys, criterion)
+		}
+	}
+	for _, k := range keys {
+		a, b := higher.Criteria[k], lower.Criteria[k]
+		if k == "attribute" {
+			a = criterionValue{Value: attributeValue(a.Value), LowerIsBest: true}
+			b = criterionValue{Value: attributeValue(b.Value), LowerIsBest: true}
+		}
+		if fmt.Sprint(a.Value) != fmt.Sprint(b.Value) {
+			c.DecidedBy = k
+			c.Detail = fmt.Sprintf("%s has %s %v, %s has %v", higher.ObjectID, k, a.Value, lower.ObjectID, b.Value)
+			if a.LowerIsBest {
+				c.Detail += " (lower ranks higher)"
+			}
+			return c
+		}
+	}
+	c.DecidedBy = "tie"
+	c.Detail = "the records are equal on every criterion; their order depends on the engine's internal tie-breaking"
+	return c
+}
+
+func attributeValue(v any) any {
+	if m, ok := v.(map[string]any); ok {
+		return m["firstMatchedWord"]
+	}
+	return v
+}
+
+// rankingAttribute returns the attribute of an asc(...) or desc(...)
+// ranking criterion.
+func rankingAttribute(criterion string) string {
+	for _, prefix := range []string{"asc(", "desc("} {
+		if strings.HasPrefix(criterion, prefix) && strings.HasSuffix(criterion, ")") {
+			return strings.TrimSuffix(strings.TrimPrefix(criterion, prefix), ")")
+		}
+	}
+	return criterion
+}
+
+// lookup returns the value of a possibly nested attribute (e.g. "stats.sales").
+func lookup(record map[string]any, attribute string) any {
+	var v any = record
+	for _, part := range strings.Split(attribute, ".") {
+		m, ok := v.(map[string]any)
+		if !ok {
+			return nil
+		}
+		v = m[part]
+	}
+	return v
+}
+
+// alternativeMatches lists the highlighted words of a hit that aren't words
+// of the query, i.e. matches through synonyms, plurals, typos or prefixes.
+func alternativeMatches(q string, highlight any) []string {
+	queryWords := map[string]bool{}
+	for _, w := range strings.Fields(strings.ToLower(q)) {
+		queryWords[w] = true
+	}
+	seen := map[string]bool{}
+	var matches []string
+	var walk func(v any)
+	walk = func(v any) {
+		switch v := v.(type) {
+		case map[string]any:
+			if value, ok := v["value"].(string); ok {
+				for _, m := range highlightedWord.FindAllStringSubmatch(value, -1) {
+					w := strings.ToLower(m[1])
+					if !queryWords[w] && !seen[w] {
+						seen[w] = true
+						matches = append(matches, m[1])
+					}
+				}
+				return
+			}
+			for _, child := range v {
+				walk(child)
+			}
+		case []any:
+			for _, child := range v {
+				walk(child)
+			}
+		}
+	}
+	walk(highlight)
+	return matches
+}
+
+// matchedAlternatives returns the alternatives of the given type (e.g.
+// "synonym") reported by the explain parameter.
+func matchedAlternatives(explain map[string]any, kind string) []any {
+	matched := []any{}
+	match, _ := explain["match"].(map[string]any)
+	alternatives, _ := match["alternatives"].([]any)
+	for _, a := range alternatives {
+		alt, _ := a.(map[string]any)
+		types, _ := alt["types"].([]any)
+		for _, t := range types {
+			if t == kind {
+				matched = append(matched, alt)
+				break
+			}
+		}
+	}
+	return matched
+}
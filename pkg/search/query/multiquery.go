@@ -0,0 +1,77 @@
+package query
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/call"
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/search"
+)
+
+// maxQueriesPerCall is the number of queries sent in one multi-query call.
+const maxQueriesPerCall = 50
+
+// Request is one query of a multi-query.
+type Request struct {
+	IndexName string
+	Query     string
+	// Params are search parameters as sent to the API, e.g.
+	// {"filters": "brand:Nike", "hitsPerPage": 10}.
+	Params map[string]any
+}
+
+// Result is the response to a Request.
+type Result struct {
+	Hits             []map[string]any `json:"hits"`
+	NbHits           int              `json:"nbHits"`
+	Page             int              `json:"page"`
+	HitsPerPage      int              `json:"hitsPerPage"`
+	ProcessingTimeMS int              `json:"processingTimeMS"`
+	AppliedRules     []map[string]any `json:"appliedRules,omitempty"`
+	// Explain holds the details requested with the explain parameter.
+	Explain map[string]any `json:"_explain,omitempty"`
+}
+
+// ObjectIDs returns the objectIDs of the hits, in rank order.
+func (r Result) ObjectIDs() []string {
+	ids := make([]string, 0, len(r.Hits))
+	for _, hit := range r.Hits {
+		id, _ := hit["objectID"].(string)
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+// MultipleQueries runs requests through the multi-query endpoint, in batches,
+// and returns their results in the same order. Params are sent as they are,
+// so any search parameter can be used.
+func MultipleQueries(client *search.Client, requests []Request) ([]Result, error) {
+	results := make([]Result, 0, len(requests))
+	for start := 0; start < len(requests); start += maxQueriesPerCall {
+		end := min(start+maxQueriesPerCall, len(requests))
+
+		batch := make([]map[string]any, 0, end-start)
+		for _, r := range requests[start:end] {
+			q := make(map[string]any, len(r.Params)+2)
+			for k, v := range r.Params {
+				q[k] = v
+			}
+			q["indexName"] = r.IndexName
+			q["query"] = r.Query
+			batch = append(batch, q)
+		}
+		body := map[string]any{"requests": batch}
+
+		var res struct {
+			Results []Result `json:"results"`
+		}
+		if err := client.CustomRequest(&res, http.MethodPost, "/1/indexes/*/queries", body, call.Read); err != nil {
+			return nil, fmt.Errorf("could not run queries: %w", err)
+		}
+		if len(res.Results) != end-start {
+			return nil, fmt.Errorf("expected %d results, got %d", end-start, len(res.Results))
+		}
+		results = append(results, res.Results...)
+	}
+	return results, nil
+}
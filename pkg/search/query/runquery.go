@@ -11,11 +11,11 @@ import (
 	"github.com/mark3labs/mcp-go/server"
 
 	"github.com/algolia/algoliasearch-client-go/v3/algolia/opt"
-	"github.com/algolia/algoliasearch-client-go/v3/algolia/search"
 	"github.com/algolia/mcp/pkg/mcputil"
+	"github.com/algolia/mcp/pkg/search/indexcache"
 )
 
-func RegisterRunQuery(mcps *server.MCPServer, client *search.Client, index *search.Index) {
+func RegisterRunQuery(mcps *server.MCPServer, cache *indexcache.Cache) {
 	runQueryTool := mcp.NewTool(
 		"run_query",
 		mcp.WithDescription("Run a query against the Algolia search index with advanced options"),
@@ -26,7 +26,7 @@ func RegisterRunQuery(mcps *server.MCPServer, client *search.Client, index *sear
 		),
 		mcp.WithString(
 			"indexName",
-			mcp.Description("The index to search into"),
+			mcp.Description(indexcache.IndexNameDescription),
 		),
 		mcp.WithNumber(
 			"hitsPerPage",
@@ -51,7 +51,11 @@ func RegisterRunQuery(mcps *server.MCPServer, client *search.Client, index *sear
 	)
 
 	mcps.AddTool(runQueryTool, func(_ context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		indexName, _ := req.Params.Arguments["indexName"].(string)
+		index, err := cache.FromRequest(req)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
 		query, _ := req.Params.Arguments["query"].(string)
 
 		opts := []any{}
@@ -85,13 +89,8 @@ func RegisterRunQuery(mcps *server.MCPServer, client *search.Client, index *sear
 			opts = append(opts, opt.RestrictSearchableAttributes(attrList...))
 		}
 
-		currentIndex := index
-		if indexName != "" {
-			currentIndex = client.InitIndex(indexName)
-		}
-
 		start := time.Now()
-		resp, err := currentIndex.Search(query, opts...)
+		resp, err := index.Search(query, opts...)
 		if err != nil {
 			return nil, fmt.Errorf("could not search: %w", err)
 		}
@@ -0,0 +1,163 @@
+package records
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/opt"
+	"github.com/algolia/mcp/pkg/mcputil"
+	"github.com/algolia/mcp/pkg/search/indexcache"
+)
+
+// deleteBySampleSize is the number of matching objectIDs shown in the preview.
+const deleteBySampleSize = 10
+
+func RegisterDeleteBy(mcps *server.MCPServer, cache *indexcache.Cache) {
+	deleteByTool := mcp.NewTool(
+		"delete_by",
+		mcp.WithDescription("Delete all objects matching the given filters. Without confirm set to true, only previews how many records match and a sample of their object IDs"),
+		mcp.WithString(
+			"indexName",
+			mcp.Description(indexcache.IndexNameDescription),
+		),
+		mcp.WithString(
+			"filters",
+			mcp.Description("The filter expression using Algolia's filter syntax (e.g., 'category:Book AND price < 100')"),
+		),
+		mcp.WithString(
+			"facetFilters",
+			mcp.Description("Facet filters as a JSON string (e.g., [\"brand:Nike\", [\"color:red\", \"color:blue\"]])"),
+		),
+		mcp.WithString(
+			"numericFilters",
+			mcp.Description("Numeric filters as a JSON string (e.g., [\"price < 100\"])"),
+		),
+		mcp.WithString(
+			"tagFilters",
+			mcp.Description("Tag filters as a JSON string (e.g., [\"published\"])"),
+		),
+		mcp.WithString(
+			"aroundLatLng",
+			mcp.Description("Coordinates of the center of a circular geo filter, as 'lat,lng'"),
+		),
+		mcp.WithNumber(
+			"aroundRadius",
+			mcp.Description("Radius in meters of the circular geo filter around aroundLatLng"),
+		),
+		mcp.WithString(
+			"insideBoundingBox",
+			mcp.Description("Rectangular area to filter on, as 'lat1,lng1,lat2,lng2'"),
+		),
+		mcp.WithString(
+			"insidePolygon",
+			mcp.Description("Polygon to filter on, as 'lat1,lng1,lat2,lng2,lat3,lng3,...'"),
+		),
+		mcp.WithBoolean(
+			"confirm",
+			mcp.Description("Set to true to actually delete the matching records after reviewing the preview"),
+		),
+	)
+
+	mcps.AddTool(deleteByTool, func(_ context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		index, err := cache.FromRequest(req)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		opts := []any{}
+		if filters, ok := req.Params.Arguments["filters"].(string); ok && filters != "" {
+			opts = append(opts, opt.Filters(filters))
+		}
+		if s, ok := req.Params.Arguments["facetFilters"].(string); ok && s != "" {
+			var o opt.FacetFiltersOption
+			if err := o.UnmarshalJSON([]byte(s)); err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("invalid facetFilters JSON: %v", err)), nil
+			}
+			opts = append(opts, &o)
+		}
+		if s, ok := req.Params.Arguments["numericFilters"].(string); ok && s != "" {
+			var o opt.NumericFiltersOption
+			if err := o.UnmarshalJSON([]byte(s)); err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("invalid numericFilters JSON: %v", err)), nil
+			}
+			opts = append(opts, &o)
+		}
+		if s, ok := req.Params.Arguments["tagFilters"].(string); ok && s != "" {
+			var o opt.TagFiltersOption
+			if err := o.UnmarshalJSON([]byte(s)); err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("invalid tagFilters JSON: %v", err)), nil
+			}
+			opts = append(opts, &o)
+		}
+		if s, ok := req.Params.Arguments["aroundLatLng"].(string); ok && s != "" {
+			opts = append(opts, opt.AroundLatLng(s))
+		}
+		if radius, ok := req.Params.Arguments["aroundRadius"].(float64); ok {
+			opts = append(opts, opt.AroundRadius(int(radius)))
+		}
+		if s, ok := req.Params.Arguments["insideBoundingBox"].(string); ok && s != "" {
+			opts = append(opts, opt.InsideBoundingBoxFromCoordinates(s))
+		}
+		if s, ok := req.Params.Arguments["insidePolygon"].(string); ok && s != "" {
+			opts = append(opts, opt.InsidePolygonFromCoordinates(s))
+		}
+
+		if len(opts) == 0 {
+			return mcp.NewToolResultError("at least one filter or geo parameter is required"), nil
+		}
+
+		// Preview the records that would be deleted. Distinct, rules and
+		// personalization are off so the count isn't lower than what the
+		// deletion removes.
+		previewOpts := append([]any{
+			opt.HitsPerPage(deleteBySampleSize),
+			opt.AttributesToRetrieve("objectID"),
+			opt.AttributesToHighlight(),
+			opt.AttributesToSnippet(),
+			opt.Analytics(false),
+			opt.DistinctOf(0),
+			opt.EnableRules(false),
+			opt.EnablePersonalization(false),
+		}, opts...)
+		preview, err := index.Search("", previewOpts...)
+		if err != nil {
+			return nil, fmt.Errorf("could not preview matching objects: %w", err)
+		}
+
+		sample := make([]string, 0, len(preview.Hits))
+		for _, hit := range preview.Hits {
+			if id, ok := hit["objectID"].(string); ok {
+				sample = append(sample, id)
+			}
+		}
+
+		confirm, _ := req.Params.Arguments["confirm"].(bool)
+		if !confirm || preview.NbHits == 0 {
+			return mcputil.JSONToolResult("delete by preview", map[string]any{
+				"indexName":        index.GetName(),
+				"matchingRecords":  preview.NbHits,
+				"exhaustiveNbHits": preview.ExhaustiveNbHits,
+				"sampleObjectIDs":  sample,
+				"deleted":          false,
+			})
+		}
+
+		res, err := index.DeleteBy(opts...)
+		if err != nil {
+			return mcp.NewToolResultError(
+				fmt.Sprintf("could not delete objects: %v", err),
+			), nil
+		}
+
+		return mcputil.JSONToolResult("delete by result", map[string]any{
+			"indexName":       index.GetName(),
+			"matchingRecords": preview.NbHits,
+			"sampleObjectIDs": sample,
+			"deleted":         true,
+			"taskID":          res.TaskID,
+		})
+	})
+}
@@ -4,16 +4,20 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/algolia/algoliasearch-client-go/v3/algolia/search"
 	"github.com/algolia/mcp/pkg/mcputil"
+	"github.com/algolia/mcp/pkg/search/indexcache"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
 )
 
-func RegisterDeleteObject(mcps *server.MCPServer, index *search.Index) {
+func RegisterDeleteObject(mcps *server.MCPServer, cache *indexcache.Cache) {
 	deleteObjectTool := mcp.NewTool(
 		"delete_object",
 		mcp.WithDescription("Delete an object by its object ID"),
+		mcp.WithString(
+			"indexName",
+			mcp.Description(indexcache.IndexNameDescription),
+		),
 		mcp.WithString(
 			"objectID",
 			mcp.Description("The object ID to delete"),
@@ -22,6 +26,11 @@ func RegisterDeleteObject(mcps *server.MCPServer, index *search.Index) {
 	)
 
 	mcps.AddTool(deleteObjectTool, func(_ context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		index, err := cache.FromRequest(req)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
 		objectID, _ := req.Params.Arguments["objectID"].(string)
 
 		res, err := index.DeleteObject(objectID)
@@ -7,14 +7,18 @@ import (
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
 
-	"github.com/algolia/algoliasearch-client-go/v3/algolia/search"
 	"github.com/algolia/mcp/pkg/mcputil"
+	"github.com/algolia/mcp/pkg/search/indexcache"
 )
 
-func RegisterGetObject(mcps *server.MCPServer, index *search.Index) {
+func RegisterGetObject(mcps *server.MCPServer, cache *indexcache.Cache) {
 	getObjectTool := mcp.NewTool(
 		"get_object",
 		mcp.WithDescription("Get an object by its object ID"),
+		mcp.WithString(
+			"indexName",
+			mcp.Description(indexcache.IndexNameDescription),
+		),
 		mcp.WithString(
 			"objectID",
 			mcp.Description("The object ID to look up"),
@@ -23,6 +27,11 @@ func RegisterGetObject(mcps *server.MCPServer, index *search.Index) {
 	)
 
 	mcps.AddTool(getObjectTool, func(_ context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		index, err := cache.FromRequest(req)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
 		objectID, _ := req.Params.Arguments["objectID"].(string)
 
 		var x map[string]any
@@ -0,0 +1,87 @@
+package records
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/search"
+	"github.com/algolia/mcp/pkg/mcputil"
+	"github.com/algolia/mcp/pkg/search/indexcache"
+)
+
+func RegisterGetObjects(mcps *server.MCPServer, cache *indexcache.Cache) {
+	getObjectsTool := mcp.NewTool(
+		"get_objects",
+		mcp.WithDescription("Get multiple objects, possibly from different indices, by their object IDs. Object IDs that don't exist are reported as missing"),
+		mcp.WithString(
+			"requests",
+			mcp.Description("Array of objects to look up as a JSON string. Example: [{\"indexName\":\"products\",\"objectID\":\"123\",\"attributesToRetrieve\":[\"name\",\"price\"]}]"),
+			mcp.Required(),
+		),
+	)
+
+	mcps.AddTool(getObjectsTool, func(_ context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		reqStr, ok := req.Params.Arguments["requests"].(string)
+		if !ok {
+			return mcp.NewToolResultError("invalid requests format, expected JSON string"), nil
+		}
+
+		var requests []struct {
+			IndexName            string   `json:"indexName"`
+			ObjectID             string   `json:"objectID"`
+			AttributesToRetrieve []string `json:"attributesToRetrieve"`
+		}
+		if err := json.Unmarshal([]byte(reqStr), &requests); err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("invalid JSON: %v", err)), nil
+		}
+
+		getRequests := make([]search.IndexedGetObject, len(requests))
+		for i, r := range requests {
+			if r.IndexName == "" || r.ObjectID == "" {
+				return mcp.NewToolResultError(fmt.Sprintf("request at index %d must include indexName and objectID", i)), nil
+			}
+			if err := cache.Allowed(r.IndexName); err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("request at index %d: %v", i, err)), nil
+			}
+			getRequests[i] = search.IndexedGetObject{
+				IndexName:            r.IndexName,
+				ObjectID:             r.ObjectID,
+				AttributesToRetrieve: strings.Join(r.AttributesToRetrieve, ","),
+			}
+		}
+
+		var objects []map[string]any
+		if err := cache.Client().MultipleGetObjects(getRequests, &objects); err != nil {
+			return mcp.NewToolResultError(
+				fmt.Sprintf("could not get objects: %v", err),
+			), nil
+		}
+
+		type objectRef struct {
+			IndexName string         `json:"indexName"`
+			ObjectID  string         `json:"objectID"`
+			Object    map[string]any `json:"object,omitempty"`
+		}
+		found := []objectRef{}
+		missing := []objectRef{}
+		for i, r := range getRequests {
+			// Results are returned in request order, with null for
+			// objects that don't exist.
+			if i < len(objects) && objects[i] != nil {
+				found = append(found, objectRef{IndexName: r.IndexName, ObjectID: r.ObjectID, Object: objects[i]})
+			} else {
+				missing = append(missing, objectRef{IndexName: r.IndexName, ObjectID: r.ObjectID})
+			}
+		}
+
+		return mcputil.JSONToolResult("objects", map[string]any{
+			"found":   found,
+			"missing": missing,
+		})
+	})
+}
@@ -8,14 +8,19 @@ import (
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
 
-	"github.com/algolia/algoliasearch-client-go/v3/algolia/search"
 	"github.com/algolia/mcp/pkg/mcputil"
+	"github.com/algolia/mcp/pkg/search/indexcache"
+	"github.com/algolia/mcp/pkg/search/recordschema"
 )
 
-func RegisterInsertObject(mcps *server.MCPServer, writeIndex *search.Index) {
+func RegisterInsertObject(mcps *server.MCPServer, cache *indexcache.Cache, validator *recordschema.Validator) {
 	insertObjectTool := mcp.NewTool(
 		"insert_object",
 		mcp.WithDescription("Insert or update an object in the Algolia index"),
+		mcp.WithString(
+			"indexName",
+			mcp.Description(indexcache.IndexNameDescription),
+		),
 		mcp.WithString(
 			"object",
 			mcp.Description("The object to insert or update as a JSON string (must include an objectID field)"),
@@ -24,8 +29,9 @@ func RegisterInsertObject(mcps *server.MCPServer, writeIndex *search.Index) {
 	)
 
 	mcps.AddTool(insertObjectTool, func(_ context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		if writeIndex == nil {
-			return mcp.NewToolResultError("write API key not set, cannot insert objects"), nil
+		index, err := cache.FromRequest(req)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
 		}
 
 		objStr, ok := req.Params.Arguments["object"].(string)
@@ -44,8 +50,12 @@ func RegisterInsertObject(mcps *server.MCPServer, writeIndex *search.Index) {
 			return mcp.NewToolResultError("object must include an objectID field"), nil
 		}
 
+		if err := validator.Validate(index.GetName(), []map[string]any{obj}); err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
 		// Save the object to the index
-		res, err := writeIndex.SaveObject(obj)
+		res, err := index.SaveObject(obj)
 		if err != nil {
 			return nil, fmt.Errorf("could not save object: %w", err)
 		}
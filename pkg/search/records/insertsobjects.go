@@ -8,14 +8,19 @@ import (
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
 
-	"github.com/algolia/algoliasearch-client-go/v3/algolia/search"
 	"github.com/algolia/mcp/pkg/mcputil"
+	"github.com/algolia/mcp/pkg/search/indexcache"
+	"github.com/algolia/mcp/pkg/search/recordschema"
 )
 
-func RegisterInsertObjects(mcps *server.MCPServer, writeIndex *search.Index) {
+func RegisterInsertObjects(mcps *server.MCPServer, cache *indexcache.Cache, validator *recordschema.Validator) {
 	insertObjectsTool := mcp.NewTool(
 		"insert_objects",
 		mcp.WithDescription("Insert or update multiple objects in the Algolia index"),
+		mcp.WithString(
+			"indexName",
+			mcp.Description(indexcache.IndexNameDescription),
+		),
 		mcp.WithString(
 			"objects",
 			mcp.Description("Array of objects to insert or update as a JSON string (each must include an objectID field)"),
@@ -24,8 +29,9 @@ func RegisterInsertObjects(mcps *server.MCPServer, writeIndex *search.Index) {
 	)
 
 	mcps.AddTool(insertObjectsTool, func(_ context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		if writeIndex == nil {
-			return mcp.NewToolResultError("write API key not set, cannot insert objects"), nil
+		index, err := cache.FromRequest(req)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
 		}
 
 		objsStr, ok := req.Params.Arguments["objects"].(string)
@@ -46,8 +52,12 @@ func RegisterInsertObjects(mcps *server.MCPServer, writeIndex *search.Index) {
 			}
 		}
 
+		if err := validator.Validate(index.GetName(), objects); err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
 		// Save the objects to the index
-		res, err := writeIndex.SaveObjects(objects)
+		res, err := index.SaveObjects(objects)
 		if err != nil {
 			return nil, fmt.Errorf("could not save objects: %w", err)
 		}
@@ -0,0 +1,144 @@
+package records
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/search"
+	"github.com/algolia/mcp/pkg/mcputil"
+	"github.com/algolia/mcp/pkg/search/indexcache"
+	"github.com/algolia/mcp/pkg/search/recordschema"
+)
+
+func RegisterMultiIndexBatch(mcps *server.MCPServer, cache *indexcache.Cache, validator *recordschema.Validator) {
+	multiIndexBatchTool := mcp.NewTool(
+		"multi_index_batch",
+		mcp.WithDescription("Apply a batch of write actions across several indices in a single request"),
+		mcp.WithString(
+			"requests",
+			mcp.Description("Array of actions as a JSON string. Each action has the form {\"action\":\"addObject\",\"indexName\":\"products\",\"body\":{...}}. "+
+				"Supported actions are addObject, updateObject, partialUpdateObject, partialUpdateObjectNoCreate and deleteObject. "+
+				"Every action except addObject requires an objectID in its body"),
+			mcp.Required(),
+		),
+		mcp.WithBoolean(
+			"wait",
+			mcp.Description("Whether to wait for the resulting indexing tasks to complete"),
+		),
+	)
+
+	mcps.AddTool(multiIndexBatchTool, func(_ context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		reqStr, ok := req.Params.Arguments["requests"].(string)
+		if !ok {
+			return mcp.NewToolResultError("invalid requests format, expected JSON string"), nil
+		}
+
+		var requests []struct {
+			Action    search.BatchAction `json:"action"`
+			IndexName string             `json:"indexName"`
+			Body      map[string]any     `json:"body"`
+		}
+		if err := json.Unmarshal([]byte(reqStr), &requests); err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("invalid JSON: %v", err)), nil
+		}
+		if len(requests) == 0 {
+			return mcp.NewToolResultError("requests must contain at least one action"), nil
+		}
+
+		// Validate every action before sending anything, so that a single
+		// malformed action does not leave the indices half-updated.
+		var problems []string
+		operations := make([]search.BatchOperationIndexed, 0, len(requests))
+		for i, r := range requests {
+			if err := validateBatchAction(r.Action, r.IndexName, r.Body); err != nil {
+				problems = append(problems, fmt.Sprintf("action at index %d: %v", i, err))
+				continue
+			}
+			if err := cache.Allowed(r.IndexName); err != nil {
+				problems = append(problems, fmt.Sprintf("action at index %d: %v", i, err))
+				continue
+			}
+			if err := validateBatchRecord(validator, r.Action, r.IndexName, r.Body); err != nil {
+				var verr *recordschema.Error
+				if !errors.As(err, &verr) {
+					problems = append(problems, fmt.Sprintf("action at index %d: %v", i, err))
+					continue
+				}
+				for _, p := range verr.Problems {
+					problems = append(problems, fmt.Sprintf("action at index %d: %s: %s", i, p.Path, p.Message))
+				}
+				continue
+			}
+			operations = append(operations, search.BatchOperationIndexed{
+				IndexName: r.IndexName,
+				BatchOperation: search.BatchOperation{
+					Action: r.Action,
+					Body:   r.Body,
+				},
+			})
+		}
+		if len(problems) > 0 {
+			return mcp.NewToolResultError(strings.Join(problems, "\n")), nil
+		}
+
+		res, err := cache.Client().MultipleBatch(operations)
+		if err != nil {
+			return mcp.NewToolResultError(
+				fmt.Sprintf("could not apply batch: %v", err),
+			), nil
+		}
+
+		if wait, _ := req.Params.Arguments["wait"].(bool); wait {
+			if err := res.Wait(); err != nil {
+				return mcp.NewToolResultError(
+					fmt.Sprintf("could not wait for batch tasks: %v", err),
+				), nil
+			}
+		}
+
+		return mcputil.JSONToolResult("multi index batch result", res)
+	})
+}
+
+// validateBatchRecord checks the record written by an action against the
+// schema of its index.
+func validateBatchRecord(validator *recordschema.Validator, action search.BatchAction, indexName string, body map[string]any) error {
+	switch action {
+	case search.AddObject, search.UpdateObject:
+		return validator.Validate(indexName, []map[string]any{body})
+	case search.PartialUpdateObject, search.PartialUpdateObjectNoCreate:
+		return validator.ValidatePartial(indexName, []map[string]any{body})
+	default:
+		return nil
+	}
+}
+
+// validateBatchAction checks a single action of a multi-index batch.
+func validateBatchAction(action search.BatchAction, indexName string, body map[string]any) error {
+	if indexName == "" {
+		return fmt.Errorf("indexName is required")
+	}
+	if body == nil {
+		return fmt.Errorf("body is required")
+	}
+
+	switch action {
+	case search.AddObject:
+		return nil
+	case search.UpdateObject, search.DeleteObject:
+		if _, exists := body["objectID"]; !exists {
+			return fmt.Errorf("%s requires an objectID field in body", action)
+		}
+		return nil
+	case search.PartialUpdateObject, search.PartialUpdateObjectNoCreate:
+		return validatePartialUpdate(body)
+	default:
+		return fmt.Errorf("unsupported action %q", action)
+	}
+}
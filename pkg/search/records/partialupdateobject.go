@@ -0,0 +1,116 @@
+package records
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/opt"
+	"github.com/algolia/mcp/pkg/mcputil"
+	"github.com/algolia/mcp/pkg/search/indexcache"
+	"github.com/algolia/mcp/pkg/search/recordschema"
+)
+
+// builtInOperations lists the values accepted in the `_operation` field of a
+// partial update attribute.
+var builtInOperations = map[string]bool{
+	"Increment":     true,
+	"Decrement":     true,
+	"Add":           true,
+	"Remove":        true,
+	"AddUnique":     true,
+	"IncrementFrom": true,
+	"IncrementSet":  true,
+}
+
+const partialUpdateDescription = "Attributes can be set to a plain value, or to a built-in operation of the form " +
+	"{\"_operation\":\"Increment\",\"value\":1}. Supported operations are Increment, Decrement, Add, Remove, " +
+	"AddUnique, IncrementFrom and IncrementSet."
+
+func RegisterPartialUpdateObject(mcps *server.MCPServer, cache *indexcache.Cache, validator *recordschema.Validator) {
+	partialUpdateObjectTool := mcp.NewTool(
+		"partial_update_object",
+		mcp.WithDescription("Update only the given attributes of an object in the Algolia index. "+partialUpdateDescription),
+		mcp.WithString(
+			"indexName",
+			mcp.Description(indexcache.IndexNameDescription),
+		),
+		mcp.WithString(
+			"object",
+			mcp.Description("The attributes to update as a JSON string (must include an objectID field)"),
+			mcp.Required(),
+		),
+		mcp.WithBoolean(
+			"createIfNotExists",
+			mcp.Description("Whether to create the object if it doesn't exist (defaults to true)"),
+		),
+	)
+
+	mcps.AddTool(partialUpdateObjectTool, func(_ context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		index, err := cache.FromRequest(req)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		objStr, ok := req.Params.Arguments["object"].(string)
+		if !ok {
+			return mcp.NewToolResultError("invalid object format, expected JSON string"), nil
+		}
+
+		// Parse the JSON string into an object
+		var obj map[string]any
+		if err := json.Unmarshal([]byte(objStr), &obj); err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("invalid JSON: %v", err)), nil
+		}
+
+		if err := validatePartialUpdate(obj); err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		if err := validator.ValidatePartial(index.GetName(), []map[string]any{obj}); err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		opts := []any{}
+		if createIfNotExists, ok := req.Params.Arguments["createIfNotExists"].(bool); ok {
+			opts = append(opts, opt.CreateIfNotExists(createIfNotExists))
+		}
+
+		res, err := index.PartialUpdateObject(obj, opts...)
+		if err != nil {
+			return nil, fmt.Errorf("could not partially update object: %w", err)
+		}
+
+		return mcputil.JSONToolResult("partial update result", res)
+	})
+}
+
+// validatePartialUpdate checks that obj has an objectID and that every
+// built-in operation it contains is well-formed.
+func validatePartialUpdate(obj map[string]any) error {
+	if _, exists := obj["objectID"]; !exists {
+		return fmt.Errorf("object must include an objectID field")
+	}
+
+	for attr, v := range obj {
+		op, ok := v.(map[string]any)
+		if !ok {
+			continue
+		}
+		name, ok := op["_operation"]
+		if !ok {
+			continue
+		}
+		s, ok := name.(string)
+		if !ok || !builtInOperations[s] {
+			return fmt.Errorf("attribute %q has unsupported _operation %v", attr, name)
+		}
+		if _, ok := op["value"]; !ok {
+			return fmt.Errorf("attribute %q: operation %s requires a value field", attr, s)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,74 @@
+package records
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/opt"
+	"github.com/algolia/mcp/pkg/mcputil"
+	"github.com/algolia/mcp/pkg/search/indexcache"
+	"github.com/algolia/mcp/pkg/search/recordschema"
+)
+
+func RegisterPartialUpdateObjects(mcps *server.MCPServer, cache *indexcache.Cache, validator *recordschema.Validator) {
+	partialUpdateObjectsTool := mcp.NewTool(
+		"partial_update_objects",
+		mcp.WithDescription("Update only the given attributes of multiple objects in the Algolia index. "+partialUpdateDescription),
+		mcp.WithString(
+			"indexName",
+			mcp.Description(indexcache.IndexNameDescription),
+		),
+		mcp.WithString(
+			"objects",
+			mcp.Description("Array of attribute sets to update as a JSON string (each must include an objectID field)"),
+			mcp.Required(),
+		),
+		mcp.WithBoolean(
+			"createIfNotExists",
+			mcp.Description("Whether to create the objects that don't exist (defaults to true)"),
+		),
+	)
+
+	mcps.AddTool(partialUpdateObjectsTool, func(_ context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		index, err := cache.FromRequest(req)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		objsStr, ok := req.Params.Arguments["objects"].(string)
+		if !ok {
+			return mcp.NewToolResultError("invalid objects format, expected JSON string"), nil
+		}
+
+		// Parse the JSON string into an array of objects
+		var objects []map[string]any
+		if err := json.Unmarshal([]byte(objsStr), &objects); err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("invalid JSON: %v", err)), nil
+		}
+
+		for i, obj := range objects {
+			if err := validatePartialUpdate(obj); err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("object at index %d: %v", i, err)), nil
+			}
+		}
+		if err := validator.ValidatePartial(index.GetName(), objects); err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		opts := []any{}
+		if createIfNotExists, ok := req.Params.Arguments["createIfNotExists"].(bool); ok {
+			opts = append(opts, opt.CreateIfNotExists(createIfNotExists))
+		}
+
+		res, err := index.PartialUpdateObjects(objects, opts...)
+		if err != nil {
+			return nil, fmt.Errorf("could not partially update objects: %w", err)
+		}
+
+		return mcputil.JSONToolResult("batch partial update result", res)
+	})
+}
@@ -0,0 +1,112 @@
+package records
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/opt"
+	"github.com/algolia/mcp/pkg/mcputil"
+	"github.com/algolia/mcp/pkg/search/indexcache"
+	"github.com/algolia/mcp/pkg/search/recordschema"
+)
+
+func RegisterReplaceAllObjects(mcps *server.MCPServer, cache *indexcache.Cache, validator *recordschema.Validator) {
+	replaceAllObjectsTool := mcp.NewTool(
+		"replace_all_objects",
+		mcp.WithDescription("Atomically replace all objects of the Algolia index with the records of a local file. "+
+			"Settings, synonyms and rules are copied to a temporary index, the records are uploaded to it, "+
+			"and the temporary index is then moved over the target. The temporary index is removed on failure"),
+		mcp.WithString(
+			"indexName",
+			mcp.Description(indexcache.IndexNameDescription),
+		),
+		mcp.WithString(
+			"file",
+			mcp.Description("Path to a local file containing the records, either as a JSON array or as newline-delimited JSON"),
+			mcp.Required(),
+		),
+	)
+
+	mcps.AddTool(replaceAllObjectsTool, func(_ context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		index, err := cache.FromRequest(req)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		path, ok := req.Params.Arguments["file"].(string)
+		if !ok || path == "" {
+			return mcp.NewToolResultError("invalid file format, expected a file path"), nil
+		}
+
+		objects, err := readObjectsFile(path)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		// Check if all objects have an objectID
+		for i, obj := range objects {
+			if _, exists := obj["objectID"]; !exists {
+				return mcp.NewToolResultError(fmt.Sprintf("object at index %d must include an objectID field", i)), nil
+			}
+		}
+		if err := validator.Validate(index.GetName(), objects); err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		// Safe mode waits for every task, including the final move, before
+		// returning.
+		if _, err := index.ReplaceAllObjects(objects, opt.Safe(true)); err != nil {
+			return mcp.NewToolResultError(
+				fmt.Sprintf("could not replace objects: %v", err),
+			), nil
+		}
+
+		return mcputil.JSONToolResult("replace all result", map[string]any{
+			"indexName": index.GetName(),
+			"nbObjects": len(objects),
+		})
+	})
+}
+
+// readObjectsFile reads records from a JSON array or newline-delimited JSON
+// file.
+func readObjectsFile(path string) ([]map[string]any, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not read file: %w", err)
+	}
+
+	var objects []map[string]any
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '[' {
+		if err := json.Unmarshal(trimmed, &objects); err != nil {
+			return nil, fmt.Errorf("invalid JSON: %w", err)
+		}
+		return objects, nil
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), 10*1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		b := bytes.TrimSpace(scanner.Bytes())
+		if len(b) == 0 {
+			continue
+		}
+		var obj map[string]any
+		if err := json.Unmarshal(b, &obj); err != nil {
+			return nil, fmt.Errorf("invalid JSON on line %d: %w", line, err)
+		}
+		objects = append(objects, obj)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("could not read file: %w", err)
+	}
+
+	return objects, nil
+}
@@ -0,0 +1,328 @@
+// Package recordschema validates records before they are written: against
+// the JSON Schema configured for their index, if any, and against the record
+// size limit.
+//
+// Schemas are configured in a JSON file of the form:
+//
+//	{
+//	  "maxRecordSize": 10000,
+//	  "schemas": {
+//	    "products": "schemas/product.json",
+//	    "products_*": "schemas/product.json",
+//	    "blog": "/etc/algolia/blog.json"
+//	  }
+//	}
+//
+// Keys are index names or path.Match patterns; when several match, the
+// longest one wins. Relative schema paths are resolved from the directory of
+// the configuration file.
+package recordschema
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/santhosh-tekuri/jsonschema/v6"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+)
+
+// DefaultMaxRecordSize is the record size limit of most Algolia plans, in
+// bytes.
+const DefaultMaxRecordSize = 100_000
+
+// maxProblems is the number of problems reported before giving up, so that
+// a batch of broken records doesn't produce a huge error.
+const maxProblems = 50
+
+// Config is the content of the configuration file.
+type Config struct {
+	// MaxRecordSize is the record size limit in bytes, DefaultMaxRecordSize
+	// when 0.
+	MaxRecordSize int               `json:"maxRecordSize"`
+	Schemas       map[string]string `json:"schemas"`
+}
+
+// Validator checks records before they are written. The zero value only
+// checks the size of records.
+type Validator struct {
+	maxRecordSize int
+	schemas       []indexSchema
+}
+
+type indexSchema struct {
+	pattern string
+	file    string
+	full    *jsonschema.Schema
+	// partial is the schema without the required properties at its root,
+	// used for partial updates.
+	partial *jsonschema.Schema
+}
+
+// Problem is a validation error at a path of a record.
+type Problem struct {
+	// Record is the position of the record in the write request.
+	Record int `json:"record"`
+	// Path is a JSON pointer to the invalid value, e.g. /variants/0/price,
+	// or / for the record itself.
+	Path    string `json:"path"`
+	Message string `json:"message"`
+}
+
+func (p Problem) String() string {
+	return fmt.Sprintf("record %d at %s: %s", p.Record, p.Path, p.Message)
+}
+
+// Error is returned when records are invalid.
+type Error struct {
+	Problems []Problem
+	// Truncated is set when there were more problems than reported.
+	Truncated bool
+}
+
+func (e *Error) Error() string {
+	lines := make([]string, 0, len(e.Problems)+2)
+	lines = append(lines, "records failed validation, nothing was written:")
+	for _, p := range e.Problems {
+		lines = append(lines, "- "+p.String())
+	}
+	if e.Truncated {
+		lines = append(lines, fmt.Sprintf("- (only the first %d problems are listed)", maxProblems))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Load reads the configuration file and compiles its schemas.
+func Load(filename string) (*Validator, error) {
+	b, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("could not read schemas config: %w", err)
+	}
+	var config Config
+	if err := json.Unmarshal(b, &config); err != nil {
+		return nil, fmt.Errorf("could not parse schemas config: %w", err)
+	}
+
+	v := &Validator{maxRecordSize: config.MaxRecordSize}
+	dir := filepath.Dir(filename)
+	for pattern, file := range config.Schemas {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("invalid index pattern %q: %w", pattern, err)
+		}
+		if !filepath.IsAbs(file) {
+			file = filepath.Join(dir, file)
+		}
+		s, err := compile(file)
+		if err != nil {
+			return nil, fmt.Errorf("schema of %q: %w", pattern, err)
+		}
+		s.pattern = pattern
+		v.schemas = append(v.schemas, s)
+	}
+	sort.Slice(v.schemas, func(i, j int) bool {
+		if len(v.schemas[i].pattern) != len(v.schemas[j].pattern) {
+			return len(v.schemas[i].pattern) > len(v.schemas[j].pattern)
+		}
+		return v.schemas[i].pattern < v.schemas[j].pattern
+	})
+	return v, nil
+}
+
+// compile compiles a schema file, and its variant for partial updates.
+func compile(file string) (indexSchema, error) {
+	file, err := filepath.Abs(file)
+	if err != nil {
+		return indexSchema{}, err
+	}
+	f, err := os.Open(file)
+	if err != nil {
+		return indexSchema{}, fmt.Errorf("could not read schema: %w", err)
+	}
+	defer f.Close()
+	doc, err := jsonschema.UnmarshalJSON(f)
+	if err != nil {
+		return indexSchema{}, fmt.Errorf("could not parse schema %s: %w", file, err)
+	}
+
+	// Both variants live next to the file, so that relative references
+	// to other schemas resolve the same way.
+	partialDoc := doc
+	if m, ok := doc.(map[string]any); ok {
+		partialDoc = withoutRequired(m)
+	}
+	c := jsonschema.NewCompiler()
+	if err := c.AddResource(file, doc); err != nil {
+		return indexSchema{}, fmt.Errorf("could not load schema %s: %w", file, err)
+	}
+	if err := c.AddResource(file+".partial", partialDoc); err != nil {
+		return indexSchema{}, fmt.Errorf("could not load schema %s: %w", file, err)
+	}
+	full, err := c.Compile(file)
+	if err != nil {
+		return indexSchema{}, fmt.Errorf("invalid schema %s: %w", file, err)
+	}
+	partial, err := c.Compile(file + ".partial")
+	if err != nil {
+		return indexSchema{}, fmt.Errorf("invalid schema %s: %w", file, err)
+	}
+	return indexSchema{file: file, full: full, partial: partial}, nil
+}
+
+// withoutRequired returns a copy of a schema without the constraints on
+// missing properties at its root.
+func withoutRequired(schema map[string]any) map[string]any {
+	m := make(map[string]any, len(schema))
+	for k, v := range schema {
+		if k == "required" || k == "minProperties" || k == "dependentRequired" || k == "$id" {
+			continue
+		}
+		m[k] = v
+	}
+	return m
+}
+
+func (v *Validator) schema(indexName string) *indexSchema {
+	if v == nil {
+		return nil
+	}
+	for i, s := range v.schemas {
+		if ok, _ := path.Match(s.pattern, indexName); ok {
+			return &v.schemas[i]
+		}
+	}
+	return nil
+}
+
+// SchemaFile returns the schema file used for an index, if any.
+func (v *Validator) SchemaFile(indexName string) string {
+	if s := v.schema(indexName); s != nil {
+		return s.file
+	}
+	return ""
+}
+
+// MaxRecordSize returns the record size limit, in bytes.
+func (v *Validator) MaxRecordSize() int {
+	if v == nil || v.maxRecordSize == 0 {
+		return DefaultMaxRecordSize
+	}
+	return v.maxRecordSize
+}
+
+// Validate checks full records to be written to an index. It returns an
+// *Error listing every problem found, or nil.
+func (v *Validator) Validate(indexName string, records []map[string]any) error {
+	return v.validate(indexName, records, false)
+}
+
+// ValidatePartial checks partial updates to be applied to an index.
+// Attributes missing from an update are allowed, and attributes updated with
+// a built-in operation are only checked for size, as their resulting value
+// depends on the current record.
+func (v *Validator) ValidatePartial(indexName string, updates []map[string]any) error {
+	return v.validate(indexName, updates, true)
+}
+
+func (v *Validator) validate(indexName string, records []map[string]any, partial bool) error {
+	s := v.schema(indexName)
+	e := &Error{}
+	add := func(p Problem) bool {
+		if len(e.Problems) == maxProblems {
+			e.Truncated = true
+			return false
+		}
+		e.Problems = append(e.Problems, p)
+		return true
+	}
+
+	for i, record := range records {
+		b, err := json.Marshal(record)
+		if err != nil {
+			return fmt.Errorf("could not encode record %d: %w", i, err)
+		}
+		if len(b) > v.MaxRecordSize() {
+			if !add(Problem{Record: i, Path: "/", Message: fmt.Sprintf("record is %d bytes, over the limit of %d bytes", len(b), v.MaxRecordSize())}) {
+				break
+			}
+		}
+		if s == nil {
+			continue
+		}
+
+		schema, instance := s.full, record
+		if partial {
+			schema, instance = s.partial, withoutOperations(record)
+		}
+		if err := schema.Validate(instance); err != nil {
+			verr, ok := err.(*jsonschema.ValidationError)
+			if !ok {
+				return fmt.Errorf("could not validate record %d: %w", i, err)
+			}
+			for _, p := range problems(i, verr) {
+				if !add(p) {
+					break
+				}
+			}
+		}
+		if e.Truncated {
+			break
+		}
+	}
+
+	if len(e.Problems) == 0 {
+		return nil
+	}
+	return e
+}
+
+// withoutOperations removes the attributes updated with a built-in
+// operation, e.g. {"_operation": "Increment", "value": 1}.
+func withoutOperations(update map[string]any) map[string]any {
+	m := make(map[string]any, len(update))
+	for k, v := range update {
+		if op, ok := v.(map[string]any); ok {
+			if _, ok := op["_operation"]; ok {
+				continue
+			}
+		}
+		m[k] = v
+	}
+	return m
+}
+
+// printer formats the messages of validation errors.
+var printer = message.NewPrinter(language.English)
+
+// problems flattens a validation error into the problems at its leaves.
+func problems(record int, err *jsonschema.ValidationError) []Problem {
+	if len(err.Causes) == 0 {
+		return []Problem{{
+			Record:  record,
+			Path:    pointer(err.InstanceLocation),
+			Message: err.ErrorKind.LocalizedString(printer),
+		}}
+	}
+	result := []Problem{}
+	for _, cause := range err.Causes {
+		result = append(result, problems(record, cause)...)
+	}
+	return result
+}
+
+// pointer formats a location as a JSON pointer.
+func pointer(location []string) string {
+	if len(location) == 0 {
+		return "/"
+	}
+	var b strings.Builder
+	for _, token := range location {
+		b.WriteByte('/')
+		b.WriteString(strings.NewReplacer("~", "~0", "/", "~1").Replace(token))
+	}
+	return b.String()
+}
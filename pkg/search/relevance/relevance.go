@@ -0,0 +1,347 @@
+// Package relevance runs suites of golden queries against an index and scores
+// the results, so that relevance regressions caused by settings or rule
+// changes are caught.
+//
+// A suite is a JSON file of the form:
+//
+//	{
+//	  "indexName": "products",
+//	  "k": 10,
+//	  "params": {"clickAnalytics": false},
+//	  "queries": [
+//	    {
+//	      "id": "nike-shoes",
+//	      "query": "nike shoes",
+//	      "filters": "in_stock:true",
+//	      "expected": [
+//	        {"objectID": "123", "position": 1, "grade": 3},
+//	        {"objectID": "456", "maxPosition": 5}
+//	      ],
+//	      "mustNotAppear": ["999"],
+//	      "minNDCG": 0.8
+//	    }
+//	  ]
+//	}
+package relevance
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/algolia/mcp/pkg/search/indexcache"
+	"github.com/algolia/mcp/pkg/search/query"
+)
+
+// DefaultK is the number of hits checked when a suite doesn't set k.
+const DefaultK = 10
+
+// Suite is a list of golden queries.
+type Suite struct {
+	// IndexName is the index queried by cases that don't name one.
+	IndexName string `json:"indexName"`
+	// K is the number of hits checked and scored for each query.
+	K int `json:"k"`
+	// Params are search parameters applied to every query.
+	Params  map[string]any `json:"params"`
+	Queries []Case         `json:"queries"`
+}
+
+// Case is a golden query and what its results must contain.
+type Case struct {
+	ID            string         `json:"id"`
+	Query         string         `json:"query"`
+	IndexName     string         `json:"indexName"`
+	Filters       string         `json:"filters"`
+	Params        map[string]any `json:"params"`
+	Expected      []Expectation  `json:"expected"`
+	MustNotAppear []string       `json:"mustNotAppear"`
+	MinNDCG       *float64       `json:"minNDCG"`
+}
+
+// Expectation is a record expected in the results of a query. Positions are
+// 1-based. Without Position or MaxPosition, the record must be in the top k.
+type Expectation struct {
+	ObjectID string `json:"objectID"`
+	// Position is the exact position the record must have.
+	Position int `json:"position"`
+	// MaxPosition is the lowest position the record may have.
+	MaxPosition int `json:"maxPosition"`
+	// Grade is the relevance of the record used by NDCG (defaults to 1).
+	Grade float64 `json:"grade"`
+}
+
+// Report is the outcome of a suite run.
+type Report struct {
+	K         int          `json:"k"`
+	NbQueries int          `json:"nbQueries"`
+	NbPassed  int          `json:"nbPassed"`
+	NbFailed  int          `json:"nbFailed"`
+	MeanNDCG  *float64     `json:"meanNDCG,omitempty"`
+	MRR       *float64     `json:"mrr,omitempty"`
+	Results   []CaseResult `json:"results"`
+}
+
+// CaseResult is the outcome of a single query.
+type CaseResult struct {
+	ID             string   `json:"id"`
+	Query          string   `json:"query"`
+	IndexName      string   `json:"indexName"`
+	Passed         bool     `json:"passed"`
+	NDCG           *float64 `json:"ndcg,omitempty"`
+	ReciprocalRank *float64 `json:"reciprocalRank,omitempty"`
+	NbHits         int      `json:"nbHits"`
+	TopObjectIDs   []string `json:"topObjectIDs"`
+	Failures       []string `json:"failures,omitempty"`
+}
+
+// LoadSuite reads and validates a suite file.
+func LoadSuite(filename string) (Suite, error) {
+	b, err := os.ReadFile(filename)
+	if err != nil {
+		return Suite{}, fmt.Errorf("could not read suite: %w", err)
+	}
+	return ParseSuite(b)
+}
+
+// ParseSuite decodes and validates a suite.
+func ParseSuite(data []byte) (Suite, error) {
+	var s Suite
+	if err := json.Unmarshal(data, &s); err != nil {
+		return Suite{}, fmt.Errorf("could not parse suite: %w", err)
+	}
+	if len(s.Queries) == 0 {
+		return Suite{}, fmt.Errorf("suite has no queries")
+	}
+	if s.K == 0 {
+		s.K = DefaultK
+	}
+	if s.K < 0 {
+		return Suite{}, fmt.Errorf("k must be positive")
+	}
+	for i := range s.Queries {
+		c := &s.Queries[i]
+		if c.ID == "" {
+			c.ID = fmt.Sprintf("#%d %q", i+1, c.Query)
+		}
+		if len(c.Expected) == 0 && len(c.MustNotAppear) == 0 {
+			return Suite{}, fmt.Errorf("query %s has no expected or mustNotAppear objectIDs", c.ID)
+		}
+		for j := range c.Expected {
+			e := &c.Expected[j]
+			if e.ObjectID == "" {
+				return Suite{}, fmt.Errorf("query %s: expected entry %d has no objectID", c.ID, j+1)
+			}
+			if e.Position < 0 || e.MaxPosition < 0 || e.Grade < 0 {
+				return Suite{}, fmt.Errorf("query %s: positions and grades of %s must be positive", c.ID, e.ObjectID)
+			}
+			if e.Grade == 0 {
+				e.Grade = 1
+			}
+		}
+	}
+	return s, nil
+}
+
+// Run runs every query of the suite and checks its results. Queries are sent
+// with analytics and A/B tests turned off so that they neither skew analytics
+// nor depend on the variant they land in.
+func Run(cache *indexcache.Cache, s Suite) (Report, error) {
+	requests := make([]query.Request, len(s.Queries))
+	for i, c := range s.Queries {
+		indexName := c.IndexName
+		if indexName == "" {
+			indexName = s.IndexName
+		}
+		index, err := cache.Index(indexName)
+		if err != nil {
+			return Report{}, fmt.Errorf("query %s: %w", c.ID, err)
+		}
+
+		params := map[string]any{
+			"analytics":      false,
+			"clickAnalytics": false,
+			"enableABTest":   false,
+		}
+		for k, v := range s.Params {
+			params[k] = v
+		}
+		for k, v := range c.Params {
+			params[k] = v
+		}
+		if c.Filters != "" {
+			params["filters"] = c.Filters
+		}
+		hitsPerPage := s.K
+		for _, e := range c.Expected {
+			hitsPerPage = max(hitsPerPage, e.Position, e.MaxPosition)
+		}
+		params["hitsPerPage"] = hitsPerPage
+		params["page"] = 0
+		params["attributesToRetrieve"] = []string{"objectID"}
+		params["attributesToHighlight"] = []string{}
+		params["attributesToSnippet"] = []string{}
+
+		requests[i] = query.Request{IndexName: index.GetName(), Query: c.Query, Params: params}
+	}
+
+	results, err := query.MultipleQueries(cache.Client(), requests)
+	if err != nil {
+		return Report{}, err
+	}
+
+	report := Report{K: s.K, NbQueries: len(s.Queries), Results: make([]CaseResult, len(s.Queries))}
+	var ndcgs, rrs []float64
+	for i, c := range s.Queries {
+		r := Check(c, results[i].ObjectIDs(), s.K)
+		r.IndexName = requests[i].IndexName
+		r.NbHits = results[i].NbHits
+		report.Results[i] = r
+		if r.Passed {
+			report.NbPassed++
+		} else {
+			report.NbFailed++
+		}
+		if r.NDCG != nil {
+			ndcgs = append(ndcgs, *r.NDCG)
+		}
+		if r.ReciprocalRank != nil {
+			rrs = append(rrs, *r.ReciprocalRank)
+		}
+	}
+	report.MeanNDCG = mean(ndcgs)
+	report.MRR = mean(rrs)
+	return report, nil
+}
+
+// Check verifies the ranked objectIDs returned for a case and scores them.
+func Check(c Case, objectIDs []string, k int) CaseResult {
+	r := CaseResult{ID: c.ID, Query: c.Query, Failures: []string{}}
+
+	positions := make(map[string]int, len(objectIDs))
+	for i, id := range objectIDs {
+		if _, ok := positions[id]; !ok {
+			positions[id] = i + 1
+		}
+	}
+	r.TopObjectIDs = objectIDs[:min(k, len(objectIDs))]
+
+	for _, e := range c.Expected {
+		pos, found := positions[e.ObjectID]
+		switch {
+		case e.Position > 0 && !found:
+			r.Failures = append(r.Failures, fmt.Sprintf("expected %s at position %d, not found", e.ObjectID, e.Position))
+		case e.Position > 0 && pos != e.Position:
+			r.Failures = append(r.Failures, fmt.Sprintf("expected %s at position %d, found at %d", e.ObjectID, e.Position, pos))
+		case e.MaxPosition > 0 && (!found || pos > e.MaxPosition):
+			r.Failures = append(r.Failures, fmt.Sprintf("expected %s within the top %d, %s", e.ObjectID, e.MaxPosition, foundAt(pos, found)))
+		case e.Position == 0 && e.MaxPosition == 0 && (!found || pos > k):
+			r.Failures = append(r.Failures, fmt.Sprintf("expected %s within the top %d, %s", e.ObjectID, k, foundAt(pos, found)))
+		}
+	}
+	for _, id := range c.MustNotAppear {
+		if pos, found := positions[id]; found && pos <= k {
+			r.Failures = append(r.Failures, fmt.Sprintf("%s must not appear in the top %d, found at %d", id, k, pos))
+		}
+	}
+
+	if len(c.Expected) > 0 {
+		ndcg := NDCG(c.Expected, objectIDs, k)
+		rr := ReciprocalRank(c.Expected, objectIDs, k)
+		r.NDCG, r.ReciprocalRank = &ndcg, &rr
+		if c.MinNDCG != nil && ndcg < *c.MinNDCG {
+			r.Failures = append(r.Failures, fmt.Sprintf("NDCG@%d is %.3f, below the minimum of %.3f", k, ndcg, *c.MinNDCG))
+		}
+	}
+
+	r.Passed = len(r.Failures) == 0
+	return r
+}
+
+func foundAt(pos int, found bool) string {
+	if !found {
+		return "not found"
+	}
+	return fmt.Sprintf("found at %d", pos)
+}
+
+// NDCG returns the normalized discounted cumulative gain of the top k
+// objectIDs, using the grades of the expected records and a gain of
+// 2^grade - 1.
+func NDCG(expected []Expectation, objectIDs []string, k int) float64 {
+	grades := make(map[string]float64, len(expected))
+	ideal := make([]float64, 0, len(expected))
+	for _, e := range expected {
+		grades[e.ObjectID] = e.Grade
+		ideal = append(ideal, e.Grade)
+	}
+	sort.Sort(sort.Reverse(sort.Float64Slice(ideal)))
+
+	var dcg, idcg float64
+	for i := 0; i < k && i < len(objectIDs); i++ {
+		dcg += (math.Pow(2, grades[objectIDs[i]]) - 1) / math.Log2(float64(i+2))
+	}
+	for i := 0; i < k && i < len(ideal); i++ {
+		idcg += (math.Pow(2, ideal[i]) - 1) / math.Log2(float64(i+2))
+	}
+	if idcg == 0 {
+		return 0
+	}
+	return dcg / idcg
+}
+
+// ReciprocalRank returns 1/position of the first expected record in the top
+// k objectIDs, or 0 if there is none.
+func ReciprocalRank(expected []Expectation, objectIDs []string, k int) float64 {
+	want := make(map[string]bool, len(expected))
+	for _, e := range expected {
+		want[e.ObjectID] = true
+	}
+	for i := 0; i < k && i < len(objectIDs); i++ {
+		if want[objectIDs[i]] {
+			return 1 / float64(i+1)
+		}
+	}
+	return 0
+}
+
+func mean(values []float64) *float64 {
+	if len(values) == 0 {
+		return nil
+	}
+	var sum float64
+	for _, v := range values {
+		sum += v
+	}
+	m := sum / float64(len(values))
+	return &m
+}
+
+// Format renders a report as text, one line per query followed by its
+// failures, and a summary line.
+func Format(r Report) string {
+	var sb strings.Builder
+	for _, c := range r.Results {
+		status := "PASS"
+		if !c.Passed {
+			status = "FAIL"
+		}
+		fmt.Fprintf(&sb, "%s %s", status, c.ID)
+		if c.NDCG != nil {
+			fmt.Fprintf(&sb, "  ndcg@%d=%.3f rr=%.3f", r.K, *c.NDCG, *c.ReciprocalRank)
+		}
+		sb.WriteString("\n")
+		for _, f := range c.Failures {
+			fmt.Fprintf(&sb, "    - %s\n", f)
+		}
+	}
+	fmt.Fprintf(&sb, "\n%d/%d queries passed", r.NbPassed, r.NbQueries)
+	if r.MeanNDCG != nil {
+		fmt.Fprintf(&sb, ", mean NDCG@%d %.3f, MRR %.3f", r.K, *r.MeanNDCG, *r.MRR)
+	}
+	sb.WriteString("\n")
+	return sb.String()
+}
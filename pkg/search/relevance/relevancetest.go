@@ -0,0 +1,87 @@
+package relevance
+
+import (
+	"context"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/algolia/mcp/pkg/mcputil"
+	"github.com/algolia/mcp/pkg/search/indexcache"
+)
+
+func RegisterRelevanceTest(mcps *server.MCPServer, cache *indexcache.Cache) {
+	relevanceTestTool := mcp.NewTool(
+		"relevance_test",
+		mcp.WithDescription("Run a suite of golden queries and check that expected records appear at the right positions and excluded ones don't. Returns pass/fail results per query with NDCG@k and MRR scores. Run it before and after a settings or rule change to catch relevance regressions"),
+		mcp.WithString(
+			"suite",
+			mcp.Description("Path of the suite JSON file"),
+		),
+		mcp.WithString(
+			"suiteJSON",
+			mcp.Description(`The suite as JSON, instead of a file: {"indexName": "...", "k": 10, "params": {...}, "queries": [{"id": "...", "query": "...", "filters": "...", "params": {...}, "expected": [{"objectID": "...", "position": 1, "maxPosition": 3, "grade": 2}], "mustNotAppear": ["..."], "minNDCG": 0.8}]}`),
+		),
+		mcp.WithString(
+			"indexName",
+			mcp.Description("The index to run every query against, overriding the suite (e.g. a replica with new settings)"),
+		),
+		mcp.WithNumber(
+			"k",
+			mcp.Description("The number of hits to check and score, overriding the suite (defaults to 10)"),
+		),
+		mcp.WithBoolean(
+			"onlyFailures",
+			mcp.Description("Whether to leave out the results of passing queries"),
+		),
+	)
+
+	mcps.AddTool(relevanceTestTool, func(_ context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		var (
+			s   Suite
+			err error
+		)
+		filename, _ := req.Params.Arguments["suite"].(string)
+		suiteJSON, _ := req.Params.Arguments["suiteJSON"].(string)
+		switch {
+		case filename != "" && suiteJSON != "":
+			return mcp.NewToolResultError("suite and suiteJSON are mutually exclusive"), nil
+		case filename != "":
+			s, err = LoadSuite(filename)
+		case suiteJSON != "":
+			s, err = ParseSuite([]byte(suiteJSON))
+		default:
+			return mcp.NewToolResultError("suite or suiteJSON parameter is required"), nil
+		}
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		if indexName, ok := req.Params.Arguments["indexName"].(string); ok && indexName != "" {
+			s.IndexName = indexName
+			for i := range s.Queries {
+				s.Queries[i].IndexName = ""
+			}
+		}
+		if k, ok := req.Params.Arguments["k"].(float64); ok && k > 0 {
+			s.K = int(k)
+		}
+
+		report, err := Run(cache, s)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		if onlyFailures, _ := req.Params.Arguments["onlyFailures"].(bool); onlyFailures {
+			failed := []CaseResult{}
+			for _, r := range report.Results {
+				if !r.Passed {
+					failed = append(failed, r)
+				}
+			}
+			report.Results = failed
+		}
+
+		return mcputil.JSONToolResult("relevance test report", report)
+	})
+}
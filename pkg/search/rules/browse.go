@@ -0,0 +1,32 @@
+package rules
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/opt"
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/search"
+)
+
+// pageSize is the number of rules fetched per page, the API maximum.
+const pageSize = 1000
+
+// Browse fetches every rule of the index.
+func Browse(index *search.Index) ([]search.Rule, error) {
+	it, err := index.BrowseRules(opt.HitsPerPage(pageSize))
+	if err != nil {
+		return nil, fmt.Errorf("could not browse rules: %w", err)
+	}
+	rules := []search.Rule{}
+	for {
+		rule, err := it.Next()
+		if errors.Is(err, io.EOF) {
+			return rules, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("could not browse rules: %w", err)
+		}
+		rules = append(rules, *rule)
+	}
+}
@@ -0,0 +1,555 @@
+package rules
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/search"
+	"github.com/algolia/mcp/pkg/mcputil"
+	"github.com/algolia/mcp/pkg/search/indexcache"
+	"github.com/algolia/mcp/pkg/search/query"
+)
+
+// Trigger statuses of a rule.
+const (
+	triggers       = "triggers"
+	mayTrigger     = "may trigger"
+	doesNotTrigger = "does not trigger"
+)
+
+// verifyWindow is how far the simulated date may be from now for the
+// prediction to be checked against a real search.
+const verifyWindow = time.Minute
+
+// simulatedRule is the outcome of a rule for the simulated search.
+type simulatedRule struct {
+	ObjectID    string         `json:"objectID"`
+	Description string         `json:"description,omitempty"`
+	Status      string         `json:"status"`
+	Reasons     []string       `json:"reasons"`
+	Consequence map[string]any `json:"consequence,omitempty"`
+	// Applied is set when the prediction was checked with a real search.
+	Applied *bool `json:"applied,omitempty"`
+
+	query *search.RuleQuery
+}
+
+func RegisterSimulateRules(mcps *server.MCPServer, cache *indexcache.Cache) {
+	simulateRulesTool := mcp.NewTool(
+		"simulate_rules",
+		mcp.WithDescription("Simulate which rules trigger for a search: evaluates every rule of the index against the query, rule contexts, filters and date (anchoring, validity periods, alternatives), tells why each rule does or does not trigger and what its consequence does (promoted and hidden objects, query edits, search parameters), then checks the prediction against the rules applied by a real search"),
+		mcp.WithString(
+			"indexName",
+			mcp.Description(indexcache.IndexNameDescription),
+		),
+		mcp.WithString(
+			"query",
+			mcp.Description("The query of the search"),
+		),
+		mcp.WithString(
+			"ruleContexts",
+			mcp.Description("Comma-separated list of the rule contexts of the search (e.g., 'mobile,summer_sale')"),
+		),
+		mcp.WithString(
+			"filters",
+			mcp.Description("The filter expression of the search (e.g., 'brand:Apple AND price < 100')"),
+		),
+		mcp.WithString(
+			"date",
+			mcp.Description("The date of the search, as RFC 3339 (e.g., '2025-12-24T10:00:00Z') or a Unix timestamp. Defaults to now; the prediction is only checked with a real search for the current date"),
+		),
+		mcp.WithBoolean(
+			"includeNotTriggered",
+			mcp.Description("Also list the rules that don't trigger, with the reason (default: false)"),
+		),
+	)
+
+	mcps.AddTool(simulateRulesTool, func(_ context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		index, err := cache.FromRequest(req)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		q, _ := req.Params.Arguments["query"].(string)
+		filters, _ := req.Params.Arguments["filters"].(string)
+		ruleContexts := []string{}
+		if s, ok := req.Params.Arguments["ruleContexts"].(string); ok {
+			for _, c := range strings.Split(s, ",") {
+				if c = strings.TrimSpace(c); c != "" {
+					ruleContexts = append(ruleContexts, c)
+				}
+			}
+		}
+		now := time.Now()
+		date := now
+		if s, ok := req.Params.Arguments["date"].(string); ok && s != "" {
+			if date, err = parseDate(s); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+		}
+		includeNotTriggered, _ := req.Params.Arguments["includeNotTriggered"].(bool)
+
+		rules, err := Browse(index)
+		if err != nil {
+			return nil, err
+		}
+		simulated := []*simulatedRule{}
+		for _, rule := range rules {
+			s := simulateRule(rule, q, ruleContexts, filters, date)
+			if s.Status != doesNotTrigger {
+				s.Consequence = describeConsequence(rule.Consequence)
+				if rule.Consequence.Params != nil {
+					s.query = rule.Consequence.Params.Query
+				}
+			}
+			simulated = append(simulated, s)
+		}
+
+		result := map[string]any{
+			"indexName":    index.GetName(),
+			"query":        q,
+			"ruleContexts": ruleContexts,
+			"filters":      filters,
+			"date":         date.UTC().Format(time.RFC3339),
+			"nbRules":      len(rules),
+		}
+
+		// Check the prediction against the rules a real search applies. Rules
+		// are evaluated at the time of the search, so this only makes sense
+		// for the current date.
+		if date.Sub(now).Abs() <= verifyWindow {
+			params := map[string]any{
+				"ruleContexts":         ruleContexts,
+				"getRankingInfo":       true,
+				"analytics":            false,
+				"clickAnalytics":       false,
+				"enableABTest":         false,
+				"attributesToRetrieve": []string{"objectID"},
+				"attributesToSnippet":  []string{},
+			}
+			if filters != "" {
+				params["filters"] = filters
+			}
+			results, err := query.MultipleQueries(cache.Client(), []query.Request{{IndexName: index.GetName(), Query: q, Params: params}})
+			if err != nil {
+				return nil, err
+			}
+			result["verification"] = verify(simulated, results[0])
+		} else {
+			result["verification"] = map[string]any{
+				"skipped": "the date isn't the current date, so a real search can't confirm the prediction",
+			}
+		}
+
+		triggered, maybe, notTriggered := []*simulatedRule{}, []*simulatedRule{}, []*simulatedRule{}
+		for _, s := range simulated {
+			switch s.Status {
+			case triggers:
+				triggered = append(triggered, s)
+			case mayTrigger:
+				maybe = append(maybe, s)
+			default:
+				notTriggered = append(notTriggered, s)
+			}
+		}
+		result["triggered"] = triggered
+		result["mayTrigger"] = maybe
+		result["nbNotTriggered"] = len(notTriggered)
+		if includeNotTriggered {
+			result["notTriggered"] = notTriggered
+		}
+		if edited, ok := editedQuery(q, triggered); ok {
+			result["editedQuery"] = edited
+		}
+
+		return mcputil.JSONToolResult("simulated rules", result)
+	})
+}
+
+// parseDate parses an RFC 3339 date or a Unix timestamp.
+func parseDate(s string) (time.Time, error) {
+	if ts, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return time.Unix(ts, 0), nil
+	}
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid date %q: expected RFC 3339 or a Unix timestamp", s)
+	}
+	return t, nil
+}
+
+// simulateRule evaluates a rule against a search.
+func simulateRule(rule search.Rule, q string, ruleContexts []string, filters string, date time.Time) *simulatedRule {
+	s := &simulatedRule{ObjectID: rule.ObjectID, Description: rule.Description, Status: doesNotTrigger}
+
+	if !rule.Enabled.Get() {
+		s.Reasons = []string{"the rule is disabled"}
+		return s
+	}
+	if len(rule.Validity) > 0 {
+		valid := false
+		for _, r := range rule.Validity {
+			if !date.Before(r.From) && !date.After(r.Until) {
+				valid = true
+				break
+			}
+		}
+		if !valid {
+			s.Reasons = []string{fmt.Sprintf("the rule isn't valid on %s (validity: %s)", date.UTC().Format(time.RFC3339), formatValidity(rule.Validity))}
+			return s
+		}
+	}
+
+	conditions := rule.Conditions
+	if rule.Condition != (search.RuleCondition{}) {
+		conditions = append([]search.RuleCondition{rule.Condition}, conditions...)
+	}
+	if len(conditions) == 0 {
+		s.Status = triggers
+		s.Reasons = []string{"the rule has no condition, so it applies to every search"}
+		return s
+	}
+
+	// Conditions are alternatives: the best outcome wins.
+	for i, c := range conditions {
+		status, reason := evaluateCondition(c, q, ruleContexts, filters)
+		if len(conditions) > 1 {
+			reason = fmt.Sprintf("condition %d: %s", i+1, reason)
+		}
+		s.Reasons = append(s.Reasons, reason)
+		if status == triggers || (status == mayTrigger && s.Status == doesNotTrigger) {
+			s.Status = status
+		}
+	}
+	return s
+}
+
+// evaluateCondition tells whether a rule condition matches a search.
+func evaluateCondition(c search.RuleCondition, q string, ruleContexts []string, filters string) (string, string) {
+	reasons := []string{}
+	status := triggers
+
+	if c.Context != "" {
+		if !slices.Contains(ruleContexts, c.Context) {
+			return doesNotTrigger, fmt.Sprintf("the search doesn't have the rule context %q", c.Context)
+		}
+		reasons = append(reasons, fmt.Sprintf("rule context %q is set", c.Context))
+	}
+
+	if c.Anchoring != "" {
+		matched, reason := matchPattern(c.Anchoring, c.Pattern, q)
+		switch {
+		case matched == triggers:
+			reasons = append(reasons, reason)
+		case matched == mayTrigger:
+			reasons = append(reasons, reason)
+			status = mayTrigger
+		case alternativesEnabled(c.Alternatives) && c.Pattern != "":
+			reasons = append(reasons, reason+", but alternatives are enabled, so it may still match plurals, synonyms or typos")
+			status = mayTrigger
+		default:
+			return doesNotTrigger, reason
+		}
+	}
+
+	if c.Filters != "" {
+		matched, reason := matchFilters(c.Filters, filters)
+		switch matched {
+		case doesNotTrigger:
+			return doesNotTrigger, reason
+		case mayTrigger:
+			status = mayTrigger
+		}
+		reasons = append(reasons, reason)
+	}
+
+	if len(reasons) == 0 {
+		return triggers, "the condition is empty"
+	}
+	return status, strings.Join(reasons, "; ")
+}
+
+// matchPattern matches the query against a condition pattern, word by word,
+// the way the engine does after normalizing both.
+func matchPattern(anchoring search.RulePatternAnchoring, pattern, q string) (string, string) {
+	patternWords := words(pattern)
+	queryWords := words(q)
+
+	if len(patternWords) == 0 {
+		if anchoring == search.Is {
+			if len(queryWords) == 0 {
+				return triggers, "the query is empty"
+			}
+			return doesNotTrigger, "the rule only matches an empty query"
+		}
+		return triggers, fmt.Sprintf("an empty pattern with anchoring %q matches any query", anchoring)
+	}
+
+	// Facet placeholders match any value of the facet, so the rest of the
+	// pattern is checked and the outcome depends on the facet values.
+	for _, w := range patternWords {
+		if isPlaceholder(w) {
+			for _, literal := range patternWords {
+				if !isPlaceholder(literal) && !slices.Contains(queryWords, literal) {
+					return doesNotTrigger, fmt.Sprintf("the query doesn't contain %q from pattern %q", literal, pattern)
+				}
+			}
+			return mayTrigger, fmt.Sprintf("pattern %q contains a facet placeholder, which matches the query only if it contains a value of the facet", pattern)
+		}
+	}
+
+	var matched bool
+	switch anchoring {
+	case search.Is:
+		matched = slices.Equal(queryWords, patternWords)
+	case search.StartsWith:
+		matched = len(queryWords) >= len(patternWords) && slices.Equal(queryWords[:len(patternWords)], patternWords)
+	case search.EndsWith:
+		matched = len(queryWords) >= len(patternWords) && slices.Equal(queryWords[len(queryWords)-len(patternWords):], patternWords)
+	default:
+		matched = indexOf(queryWords, patternWords) >= 0
+	}
+	if matched {
+		return triggers, fmt.Sprintf("the query matches pattern %q with anchoring %q", pattern, anchoring)
+	}
+	return doesNotTrigger, fmt.Sprintf("the query doesn't match pattern %q with anchoring %q", pattern, anchoring)
+}
+
+// matchFilters tells whether the filters of a search contain the filters of
+// a condition. Only conjunctions of simple filters can be told for sure.
+func matchFilters(conditionFilters, filters string) (string, string) {
+	if strings.Contains(conditionFilters, " OR ") || strings.ContainsAny(conditionFilters, "()") {
+		return mayTrigger, fmt.Sprintf("condition filters %q can't be evaluated locally", conditionFilters)
+	}
+	searchFilters := map[string]bool{}
+	for _, f := range strings.Split(filters, " AND ") {
+		searchFilters[normalizeFilter(f)] = true
+	}
+	for _, f := range strings.Split(conditionFilters, " AND ") {
+		if !searchFilters[normalizeFilter(f)] {
+			return doesNotTrigger, fmt.Sprintf("the search filters don't include %q", strings.TrimSpace(f))
+		}
+	}
+	return triggers, fmt.Sprintf("the search filters include %q", conditionFilters)
+}
+
+// normalizeFilter makes equivalent filters comparable, e.g. brand:"Apple"
+// and brand:Apple.
+func normalizeFilter(f string) string {
+	f = strings.TrimSpace(strings.Trim(strings.TrimSpace(f), "()"))
+	f = strings.NewReplacer(`"`, "", "'", "", " ", "").Replace(f)
+	return strings.ToLower(f)
+}
+
+// words splits a query or pattern into lowercase words, keeping facet
+// placeholders such as {facet:brand} whole.
+func words(s string) []string {
+	result := []string{}
+	var b strings.Builder
+	flush := func() {
+		if b.Len() > 0 {
+			result = append(result, b.String())
+			b.Reset()
+		}
+	}
+	inPlaceholder := false
+	for _, r := range strings.ToLower(s) {
+		switch {
+		case r == '{':
+			flush()
+			inPlaceholder = true
+			b.WriteRune(r)
+		case r == '}' && inPlaceholder:
+			b.WriteRune(r)
+			inPlaceholder = false
+			flush()
+		case inPlaceholder || unicode.IsLetter(r) || unicode.IsDigit(r):
+			b.WriteRune(r)
+		default:
+			flush()
+		}
+	}
+	flush()
+	return result
+}
+
+func isPlaceholder(w string) bool {
+	return strings.HasPrefix(w, "{") && strings.HasSuffix(w, "}")
+}
+
+// indexOf returns the position of sub in s, or -1.
+func indexOf(s, sub []string) int {
+	for i := 0; i+len(sub) <= len(s); i++ {
+		if slices.Equal(s[i:i+len(sub)], sub) {
+			return i
+		}
+	}
+	return -1
+}
+
+func alternativesEnabled(a *search.Alternatives) bool {
+	if a == nil {
+		return false
+	}
+	b, _ := json.Marshal(a)
+	return string(b) == "true"
+}
+
+func formatValidity(ranges []search.TimeRange) string {
+	parts := make([]string, 0, len(ranges))
+	for _, r := range ranges {
+		parts = append(parts, r.From.UTC().Format(time.RFC3339)+" to "+r.Until.UTC().Format(time.RFC3339))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// describeConsequence lists what a rule does to the search.
+func describeConsequence(c search.RuleConsequence) map[string]any {
+	d := map[string]any{}
+	if len(c.Promote) > 0 {
+		promoted := []map[string]any{}
+		for _, p := range c.Promote {
+			ids := p.ObjectIDs
+			if p.ObjectID != "" {
+				ids = append([]string{p.ObjectID}, ids...)
+			}
+			promoted = append(promoted, map[string]any{"objectIDs": ids, "position": p.Position})
+		}
+		d["promote"] = promoted
+		if c.FilterPromotes.Get() {
+			d["filterPromotes"] = "promoted records are only shown if they match the filters of the search"
+		}
+	}
+	if len(c.Hide) > 0 {
+		hidden := make([]string, 0, len(c.Hide))
+		for _, h := range c.Hide {
+			hidden = append(hidden, h.ObjectID)
+		}
+		d["hide"] = hidden
+	}
+	if c.UserData != nil {
+		d["userData"] = c.UserData
+	}
+	if c.Redirect != nil {
+		d["redirect"] = c.Redirect.IndexName
+	}
+	if c.Params == nil {
+		return d
+	}
+
+	if c.Params.Query != nil {
+		simple, object := c.Params.Query.Get()
+		if object != nil && len(object.Edits) > 0 {
+			edits := []string{}
+			for _, e := range object.Edits {
+				if e.Type == search.Replace {
+					edits = append(edits, fmt.Sprintf("replace %q with %q", e.Delete, e.Insert))
+				} else {
+					edits = append(edits, fmt.Sprintf("remove %q", e.Delete))
+				}
+			}
+			d["queryEdits"] = edits
+		} else {
+			d["queryReplacement"] = simple
+		}
+	}
+	if len(c.Params.AutomaticFacetFilters) > 0 {
+		d["automaticFacetFilters"] = c.Params.AutomaticFacetFilters
+	}
+	if len(c.Params.AutomaticOptionalFacetFilters) > 0 {
+		d["automaticOptionalFacetFilters"] = c.Params.AutomaticOptionalFacetFilters
+	}
+	if c.Params.RenderingContent != nil {
+		d["renderingContent"] = c.Params.RenderingContent
+	}
+
+	// The other parameters override the search parameters.
+	b, err := json.Marshal(c.Params.QueryParams)
+	if err == nil {
+		var params map[string]any
+		if json.Unmarshal(b, &params) == nil && len(params) > 0 {
+			d["params"] = params
+		}
+	}
+	return d
+}
+
+// editedQuery applies the query changes of the triggered rules to the query.
+func editedQuery(q string, triggered []*simulatedRule) (string, bool) {
+	edited, changed := q, false
+	for _, s := range triggered {
+		if s.query == nil {
+			continue
+		}
+		simple, object := s.query.Get()
+		if object == nil || len(object.Edits) == 0 {
+			edited, changed = simple, true
+			continue
+		}
+		for _, e := range object.Edits {
+			queryWords := words(edited)
+			deleted := words(e.Delete)
+			i := indexOf(queryWords, deleted)
+			if len(deleted) == 0 || i < 0 {
+				continue
+			}
+			inserted := []string{}
+			if e.Type == search.Replace {
+				inserted = words(e.Insert)
+			}
+			queryWords = slices.Concat(queryWords[:i], inserted, queryWords[i+len(deleted):])
+			edited, changed = strings.Join(queryWords, " "), true
+		}
+	}
+	return edited, changed
+}
+
+// verify compares the prediction with the rules applied by a real search.
+func verify(simulated []*simulatedRule, res query.Result) map[string]any {
+	applied := map[string]bool{}
+	for _, r := range res.AppliedRules {
+		if id, ok := r["objectID"].(string); ok {
+			applied[id] = true
+		}
+	}
+
+	confirmed, missed, unexpected := []string{}, []string{}, []string{}
+	for _, s := range simulated {
+		a := applied[s.ObjectID]
+		if s.Status == mayTrigger {
+			s.Applied = &a
+		}
+		switch {
+		case s.Status == triggers && a:
+			confirmed = append(confirmed, s.ObjectID)
+		case s.Status == triggers:
+			missed = append(missed, s.ObjectID)
+		case s.Status == doesNotTrigger && a:
+			unexpected = append(unexpected, s.ObjectID)
+		}
+		delete(applied, s.ObjectID)
+	}
+	// Rules applied but not browsed, e.g. saved in the meantime.
+	for id := range applied {
+		unexpected = append(unexpected, id)
+	}
+
+	return map[string]any{
+		"appliedRules":           res.AppliedRules,
+		"confirmed":              confirmed,
+		"predictedButNotApplied": missed,
+		"appliedButNotPredicted": unexpected,
+		"matchesPrediction":      len(missed) == 0 && len(unexpected) == 0,
+		"nbHits":                 res.NbHits,
+		"firstObjectIDs":         res.ObjectIDs(),
+	}
+}
@@ -1,43 +1,79 @@
 package search
 
 import (
-	"github.com/algolia/algoliasearch-client-go/v3/algolia/search"
+	"github.com/algolia/mcp/pkg/search/backup"
+	"github.com/algolia/mcp/pkg/search/indexcache"
 	"github.com/algolia/mcp/pkg/search/indices"
+	"github.com/algolia/mcp/pkg/search/lint"
+	"github.com/algolia/mcp/pkg/search/logs"
+	"github.com/algolia/mcp/pkg/search/profile"
 	"github.com/algolia/mcp/pkg/search/query"
 	"github.com/algolia/mcp/pkg/search/records"
+	"github.com/algolia/mcp/pkg/search/recordschema"
+	"github.com/algolia/mcp/pkg/search/relevance"
+	"github.com/algolia/mcp/pkg/search/rules"
+	"github.com/algolia/mcp/pkg/search/settingshistory"
+	"github.com/algolia/mcp/pkg/search/suggest"
+	"github.com/algolia/mcp/pkg/search/synonyms"
 	"github.com/mark3labs/mcp-go/server"
 )
 
 // RegisterAll registers all Search tools with the MCP server (both read and write).
-func RegisterAll(mcps *server.MCPServer) {
-	// Initialize Algolia client.
-	// Note: In a real implementation, you would get the app ID and API key from environment variables.
-	client := search.NewClient("", "")
-	index := client.InitIndex("default_index")
-
+func RegisterAll(mcps *server.MCPServer, cache *indexcache.Cache, history *settingshistory.Store, backupDir string, validator *recordschema.Validator) {
 	// Register both read and write operations.
-	RegisterReadAll(mcps, client, index)
-	RegisterWriteAll(mcps, client, index)
+	RegisterReadAll(mcps, cache, history)
+	RegisterWriteAll(mcps, cache, history, backupDir, validator)
 }
 
 // RegisterReadAll registers read-only Search tools with the MCP server.
-func RegisterReadAll(mcps *server.MCPServer, client *search.Client, index *search.Index) {
+func RegisterReadAll(mcps *server.MCPServer, cache *indexcache.Cache, history *settingshistory.Store) {
 	// Register read-only operations.
-	indices.RegisterList(mcps, client)
-	indices.RegisterGetSettings(mcps, index)
-	query.RegisterRunQuery(mcps, client, index)
-	records.RegisterGetObject(mcps, index)
+	indices.RegisterDiffSettings(mcps, cache)
+	indices.RegisterGetSettings(mcps, cache)
+	indices.RegisterInventory(mcps, cache)
+	indices.RegisterList(mcps, cache)
+	indices.RegisterListReplicas(mcps, cache)
+	indices.RegisterListSettingsHistory(mcps, cache, history)
+	lint.RegisterLintRulesAndSynonyms(mcps, cache)
+	logs.RegisterGetSearchLogs(mcps, cache)
+	profile.RegisterProfileIndex(mcps, cache)
+	query.RegisterCompareQueries(mcps, cache)
+	query.RegisterExplainRanking(mcps, cache)
+	query.RegisterRunQuery(mcps, cache)
+	records.RegisterGetObject(mcps, cache)
+	records.RegisterGetObjects(mcps, cache)
+	relevance.RegisterRelevanceTest(mcps, cache)
+	rules.RegisterSearchRules(mcps, cache)
+	rules.RegisterSimulateRules(mcps, cache)
+	suggest.RegisterSuggestSettings(mcps, cache)
+	synonyms.RegisterGetSynonym(mcps, cache)
+	synonyms.RegisterSearchSynonym(mcps, cache)
 }
 
 // RegisterWriteAll registers write-only Search tools with the MCP server.
-func RegisterWriteAll(mcps *server.MCPServer, client *search.Client, index *search.Index) {
+func RegisterWriteAll(mcps *server.MCPServer, cache *indexcache.Cache, history *settingshistory.Store, backupDir string, validator *recordschema.Validator) {
 	// Register write operations.
-	indices.RegisterClear(mcps, index)
-	indices.RegisterCopy(mcps, client, index)
-	indices.RegisterDelete(mcps, index)
-	indices.RegisterMove(mcps, client, index)
-	indices.RegisterSetSettings(mcps, index)
-	records.RegisterDeleteObject(mcps, index)
-	records.RegisterInsertObject(mcps, index)
-	records.RegisterInsertObjects(mcps, index)
+	backup.RegisterBackupIndex(mcps, cache, backupDir)
+	backup.RegisterRestoreIndex(mcps, cache, history, validator)
+	indices.RegisterClear(mcps, cache)
+	indices.RegisterCopy(mcps, cache, history, validator)
+	indices.RegisterCreateReplica(mcps, cache, history)
+	indices.RegisterDelete(mcps, cache)
+	indices.RegisterMove(mcps, cache, history, validator)
+	indices.RegisterPatchSettings(mcps, cache, history)
+	indices.RegisterRemoveReplica(mcps, cache, history)
+	indices.RegisterRollbackSettings(mcps, cache, history)
+	indices.RegisterSetSettings(mcps, cache, history)
+	records.RegisterDeleteBy(mcps, cache)
+	records.RegisterDeleteObject(mcps, cache)
+	records.RegisterInsertObject(mcps, cache, validator)
+	records.RegisterInsertObjects(mcps, cache, validator)
+	records.RegisterMultiIndexBatch(mcps, cache, validator)
+	records.RegisterPartialUpdateObject(mcps, cache, validator)
+	records.RegisterPartialUpdateObjects(mcps, cache, validator)
+	records.RegisterReplaceAllObjects(mcps, cache, validator)
+	rules.RegisterDeleteRule(mcps, cache)
+	synonyms.RegisterClearSynonyms(mcps, cache)
+	synonyms.RegisterDeleteSynonym(mcps, cache)
+	synonyms.RegisterInsertSynonym(mcps, cache)
 }
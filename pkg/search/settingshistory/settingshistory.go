@@ -0,0 +1,164 @@
+// Package settingshistory keeps local snapshots of index settings so that
+// changes made through the settings tools can be rolled back.
+package settingshistory
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/search"
+
+	"github.com/algolia/mcp/pkg/mcputil"
+)
+
+// idFormat is the layout of snapshot IDs. It sorts lexically in time order.
+const idFormat = "20060102T150405.000000000Z"
+
+// Snapshot is the settings of an index at a point in time.
+type Snapshot struct {
+	ID        string         `json:"id"`
+	IndexName string         `json:"indexName"`
+	CreatedAt time.Time      `json:"createdAt"`
+	Reason    string         `json:"reason"`
+	Settings  map[string]any `json:"settings"`
+}
+
+// Store persists snapshots as JSON files under a directory, one
+// sub-directory per application and index.
+type Store struct {
+	dir   string
+	appID string
+}
+
+// New creates a Store rooted at dir for the given application.
+func New(dir, appID string) *Store {
+	return &Store{dir: dir, appID: appID}
+}
+
+// DefaultDir returns the directory used when ALGOLIA_SETTINGS_HISTORY_DIR is
+// not set.
+func DefaultDir() string {
+	return mcputil.DataDir("settings-history")
+}
+
+// ForApp returns a Store for another application, sharing the directory.
+func (s *Store) ForApp(appID string) *Store {
+	return &Store{dir: s.dir, appID: appID}
+}
+
+// SaveIndex records the current settings of an index, in the history of the
+// application it belongs to, and returns the new snapshot.
+func (s *Store) SaveIndex(index *search.Index, reason string) (Snapshot, error) {
+	settings, err := index.GetSettings()
+	if err != nil {
+		return Snapshot{}, fmt.Errorf("could not get settings of %q: %w", index.GetName(), err)
+	}
+	b, err := json.Marshal(settings)
+	if err != nil {
+		return Snapshot{}, fmt.Errorf("could not marshal settings: %w", err)
+	}
+	var current map[string]any
+	if err := json.Unmarshal(b, &current); err != nil {
+		return Snapshot{}, fmt.Errorf("could not unmarshal settings: %w", err)
+	}
+	return s.ForApp(index.GetAppID()).Save(index.GetName(), current, reason)
+}
+
+func (s *Store) indexDir(indexName string) string {
+	return filepath.Join(s.dir, url.PathEscape(s.appID), url.PathEscape(indexName))
+}
+
+// Save records the given settings of an index and returns the new snapshot.
+func (s *Store) Save(indexName string, settings map[string]any, reason string) (Snapshot, error) {
+	now := time.Now().UTC()
+	snapshot := Snapshot{
+		ID:        now.Format(idFormat),
+		IndexName: indexName,
+		CreatedAt: now,
+		Reason:    reason,
+		Settings:  settings,
+	}
+
+	dir := s.indexDir(indexName)
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return Snapshot{}, fmt.Errorf("could not create history directory: %w", err)
+	}
+
+	b, err := json.MarshalIndent(snapshot, "", "  ")
+	if err != nil {
+		return Snapshot{}, fmt.Errorf("could not marshal snapshot: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, snapshot.ID+".json"), b, 0o600); err != nil {
+		return Snapshot{}, fmt.Errorf("could not write snapshot: %w", err)
+	}
+
+	return snapshot, nil
+}
+
+// List returns the snapshots of an index, most recent first. The settings of
+// each snapshot are left out.
+func (s *Store) List(indexName string) ([]Snapshot, error) {
+	entries, err := os.ReadDir(s.indexDir(indexName))
+	if os.IsNotExist(err) {
+		return []Snapshot{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("could not read history directory: %w", err)
+	}
+
+	ids := make([]string, 0, len(entries))
+	for _, e := range entries {
+		if !e.IsDir() && strings.HasSuffix(e.Name(), ".json") {
+			ids = append(ids, strings.TrimSuffix(e.Name(), ".json"))
+		}
+	}
+	sort.Sort(sort.Reverse(sort.StringSlice(ids)))
+
+	snapshots := make([]Snapshot, 0, len(ids))
+	for _, id := range ids {
+		snapshot, err := s.Get(indexName, id)
+		if err != nil {
+			return nil, err
+		}
+		snapshot.Settings = nil
+		snapshots = append(snapshots, snapshot)
+	}
+
+	return snapshots, nil
+}
+
+// Get returns a snapshot of an index by its ID. An empty ID returns the most
+// recent snapshot.
+func (s *Store) Get(indexName, id string) (Snapshot, error) {
+	if id == "" {
+		snapshots, err := s.List(indexName)
+		if err != nil {
+			return Snapshot{}, err
+		}
+		if len(snapshots) == 0 {
+			return Snapshot{}, fmt.Errorf("no settings history for index %q", indexName)
+		}
+		id = snapshots[0].ID
+	}
+	if strings.ContainsAny(id, `/\`) {
+		return Snapshot{}, fmt.Errorf("invalid snapshot ID %q", id)
+	}
+
+	b, err := os.ReadFile(filepath.Join(s.indexDir(indexName), id+".json"))
+	if err != nil {
+		return Snapshot{}, fmt.Errorf("could not read snapshot %q: %w", id, err)
+	}
+
+	var snapshot Snapshot
+	if err := json.Unmarshal(b, &snapshot); err != nil {
+		return Snapshot{}, fmt.Errorf("could not parse snapshot %q: %w", id, err)
+	}
+
+	return snapshot, nil
+}
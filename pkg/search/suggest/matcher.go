@@ -0,0 +1,139 @@
+package suggest
+
+import (
+	"strings"
+	"unicode"
+)
+
+// maxMatchedQueries is the number of no-result searches looked up in the
+// records.
+const maxMatchedQueries = 50
+
+// queryMatcher looks for the words of no-result searches in the string
+// values of records, and measures what the string values look like.
+type queryMatcher struct {
+	queries []searchStat
+	words   [][]string
+	// hits counts, for each query, the records having all its words in the
+	// value of an attribute, by attribute path.
+	hits []map[string]int
+	// nbStrings, nbCodes and nbURLs count string values by attribute path.
+	nbStrings map[string]int
+	nbCodes   map[string]int
+	nbURLs    map[string]int
+}
+
+func newQueryMatcher(noResults []searchStat) *queryMatcher {
+	m := &queryMatcher{
+		nbStrings: map[string]int{},
+		nbCodes:   map[string]int{},
+		nbURLs:    map[string]int{},
+	}
+	for _, s := range noResults {
+		if len(m.queries) == maxMatchedQueries {
+			break
+		}
+		words := tokenize(s.Search)
+		if len(words) == 0 {
+			continue
+		}
+		m.queries = append(m.queries, s)
+		m.words = append(m.words, words)
+		m.hits = append(m.hits, map[string]int{})
+	}
+	return m
+}
+
+// visit is the profile.Build hook.
+func (m *queryMatcher) visit(record map[string]any) {
+	matched := make([]map[string]bool, len(m.queries))
+	for k, v := range record {
+		if k == "objectID" {
+			continue
+		}
+		m.walk(k, v, func(path, s string) {
+			m.nbStrings[path]++
+			if isCode(s) {
+				m.nbCodes[path]++
+			}
+			if strings.HasPrefix(s, "http://") || strings.HasPrefix(s, "https://") {
+				m.nbURLs[path]++
+			}
+			if len(m.queries) == 0 {
+				return
+			}
+			words := map[string]bool{}
+			for _, w := range tokenize(s) {
+				words[w] = true
+			}
+			for i, qwords := range m.words {
+				if containsAll(words, qwords) {
+					if matched[i] == nil {
+						matched[i] = map[string]bool{}
+					}
+					matched[i][path] = true
+				}
+			}
+		})
+	}
+	for i, paths := range matched {
+		for path := range paths {
+			m.hits[i][path]++
+		}
+	}
+}
+
+// walk calls fn with the string values of v, using the same paths as the
+// profile.
+func (m *queryMatcher) walk(path string, v any, fn func(path, s string)) {
+	switch v := v.(type) {
+	case string:
+		fn(path, v)
+	case map[string]any:
+		for k, child := range v {
+			m.walk(path+"."+k, child, fn)
+		}
+	case []any:
+		for _, item := range v {
+			m.walk(path, item, fn)
+		}
+	}
+}
+
+// tokenize splits text into lowercase words, as the engine roughly does.
+func tokenize(s string) []string {
+	return strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+func containsAll(set map[string]bool, words []string) bool {
+	for _, w := range words {
+		if !set[w] {
+			return false
+		}
+	}
+	return true
+}
+
+// isCode tells whether a value looks like a reference, e.g. a SKU or a part
+// number, rather than text: a single token made of digits, possibly mixed
+// with letters and separators.
+func isCode(s string) bool {
+	if len(s) < 3 || len(s) > 40 {
+		return false
+	}
+	digits, letters := 0, 0
+	for _, r := range s {
+		switch {
+		case unicode.IsDigit(r):
+			digits++
+		case unicode.IsLetter(r):
+			letters++
+		case r == '-' || r == '_' || r == '.' || r == '/':
+		default:
+			return false
+		}
+	}
+	return digits > 0 && (letters > 0 || digits >= 4)
+}
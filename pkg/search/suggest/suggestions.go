@@ -0,0 +1,714 @@
+package suggest
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/search"
+
+	"github.com/algolia/mcp/pkg/search/profile"
+)
+
+// Categories of suggestions.
+const (
+	categorySettingsFix = "settingsFix"
+	categorySearchable  = "searchableAttributes"
+	categoryFaceting    = "attributesForFaceting"
+	categorySynonym     = "synonym"
+	categoryTypo        = "typoTolerance"
+	categoryRanking     = "customRanking"
+)
+
+const (
+	// minCodeShare is the share of code-like values above which an attribute
+	// is considered to hold codes.
+	minCodeShare = 0.5
+	// minNumericSearchShare is the share of searches with numeric tokens
+	// above which typos on numbers are worth disabling.
+	minNumericSearchShare = 0.1
+	// longText is the average length above which a searchable attribute is
+	// made unordered, as the position of words in long text means little.
+	longText = 100
+)
+
+// popularityWords are words in the names of attributes that are good custom
+// ranking candidates.
+var popularityWords = []string{"popularity", "sales", "sold", "rating", "views", "likes", "reviews", "downloads", "score"}
+
+// suggestion is a recommended change.
+type suggestion struct {
+	ID       string `json:"id"`
+	Category string `json:"category"`
+	Title    string `json:"title"`
+	// Rationale explains why the change would help.
+	Rationale string `json:"rationale"`
+	Evidence  any    `json:"evidence,omitempty"`
+	// Confidence is high, medium or low.
+	Confidence string `json:"confidence"`
+	Apply      apply  `json:"apply"`
+
+	// edit applies the change to the settings, for settings suggestions.
+	edit func(d *draft)
+}
+
+// apply is the tool call applying a suggestion.
+type apply struct {
+	Tool      string         `json:"tool"`
+	Arguments map[string]any `json:"arguments"`
+}
+
+// draft is a copy of the settings that suggestions are applied to.
+type draft struct {
+	values  map[string]any
+	touched []string
+}
+
+func (d *draft) list(key string) []string {
+	items, _ := d.values[key].([]any)
+	result := make([]string, 0, len(items))
+	for _, item := range items {
+		if s, ok := item.(string); ok {
+			result = append(result, s)
+		}
+	}
+	return result
+}
+
+func (d *draft) set(key string, v any) {
+	if s, ok := v.([]string); ok {
+		items := make([]any, len(s))
+		for i, item := range s {
+			items[i] = item
+		}
+		v = items
+	}
+	d.values[key] = v
+	if !slices.Contains(d.touched, key) {
+		d.touched = append(d.touched, key)
+	}
+}
+
+// appendTo adds entries at the end of a list setting, skipping the ones
+// already in it.
+func (d *draft) appendTo(key string, entries ...string) {
+	list := d.list(key)
+	for _, e := range entries {
+		if !slices.Contains(list, e) {
+			list = append(list, e)
+		}
+	}
+	d.set(key, list)
+}
+
+// patch returns the touched settings.
+func (d *draft) patch() map[string]any {
+	patch := make(map[string]any, len(d.touched))
+	for _, key := range d.touched {
+		patch[key] = d.values[key]
+	}
+	return patch
+}
+
+// advisor turns what is known about an index into suggestions.
+type advisor struct {
+	indexName string
+	settings  search.Settings
+	profile   *profile.Profile
+	usage     usage
+	matches   *queryMatcher
+}
+
+func (a *advisor) suggest() []suggestion {
+	suggestions := []suggestion{}
+	suggestions = append(suggestions, a.settingsFixes()...)
+	suggestions = append(suggestions, a.searchableAttributes()...)
+	suggestions = append(suggestions, a.facetingAttributes()...)
+	suggestions = append(suggestions, a.synonyms()...)
+	suggestions = append(suggestions, a.typoTolerance()...)
+	suggestions = append(suggestions, a.customRanking()...)
+
+	for i, s := range suggestions {
+		if s.edit == nil {
+			continue
+		}
+		d := a.draft()
+		s.edit(d)
+		suggestions[i].Apply = a.patchSettings(d.patch())
+	}
+	return suggestions
+}
+
+// combinedPatch applies every settings suggestion at once.
+func (a *advisor) combinedPatch(suggestions []suggestion) *apply {
+	d := a.draft()
+	for _, s := range suggestions {
+		if s.edit != nil {
+			s.edit(d)
+		}
+	}
+	if len(d.touched) == 0 {
+		return nil
+	}
+	patch := a.patchSettings(d.patch())
+	return &patch
+}
+
+func (a *advisor) draft() *draft {
+	d := &draft{values: map[string]any{}}
+	b, err := json.Marshal(a.settings)
+	if err == nil {
+		_ = json.Unmarshal(b, &d.values)
+	}
+	return d
+}
+
+func (a *advisor) patchSettings(patch map[string]any) apply {
+	b, _ := json.Marshal(patch)
+	return apply{
+		Tool:      "patch_settings",
+		Arguments: map[string]any{"indexName": a.indexName, "settings": string(b)},
+	}
+}
+
+// sampled tells how much of the index the profile covers, for rationales.
+func (a *advisor) sampled() string {
+	if a.profile.NbProfiled < a.profile.NbRecords {
+		return fmt.Sprintf("%d of the %d records", a.profile.NbProfiled, a.profile.NbRecords)
+	}
+	return fmt.Sprintf("the %d records", a.profile.NbProfiled)
+}
+
+// settingsFixes removes the entries of list settings that refer to missing
+// attributes or to attributes that can't be used for them.
+func (a *advisor) settingsFixes() []suggestion {
+	suggestions := []suggestion{}
+	for _, issue := range profile.CheckSettings(a.profile, a.settings) {
+		if issue.Severity == "info" || issue.Setting == "attributeForDistinct" {
+			continue
+		}
+		confidence := "high"
+		if issue.Severity == "warning" && a.profile.NbProfiled < a.profile.NbRecords {
+			confidence = "low"
+		}
+		setting, attr := issue.Setting, issue.Attribute
+		suggestions = append(suggestions, suggestion{
+			ID:         fmt.Sprintf("fix:%s:%s", setting, attr),
+			Category:   categorySettingsFix,
+			Title:      fmt.Sprintf("Remove %q from %s", attr, setting),
+			Rationale:  issue.Message + ".",
+			Confidence: confidence,
+			edit: func(d *draft) {
+				d.set(setting, withoutAttribute(d.list(setting), attr))
+			},
+		})
+	}
+	return suggestions
+}
+
+// withoutAttribute removes an attribute from the entries of a list setting,
+// whatever its modifier, keeping the other attributes of entries grouping
+// several of them, e.g. "title,alternative_title".
+func withoutAttribute(entries []string, attr string) []string {
+	result := []string{}
+	for _, entry := range entries {
+		kept := []string{}
+		for _, part := range strings.Split(entry, ",") {
+			if attributeOf(strings.TrimSpace(part)) != attr {
+				kept = append(kept, strings.TrimSpace(part))
+			}
+		}
+		if len(kept) > 0 {
+			result = append(result, strings.Join(kept, ","))
+		}
+	}
+	return result
+}
+
+// attributeOf returns the attribute of a setting entry, without modifier.
+func attributeOf(entry string) string {
+	for _, m := range []string{"unordered", "ordered", "searchable", "filterOnly", "afterDistinct", "asc", "desc"} {
+		if strings.HasPrefix(entry, m+"(") && strings.HasSuffix(entry, ")") {
+			return entry[len(m)+1 : len(entry)-1]
+		}
+	}
+	return entry
+}
+
+// searchable tells whether searches match an attribute.
+func (a *advisor) searchable(path string) bool {
+	entries := a.settings.SearchableAttributes.Get()
+	if len(entries) == 0 {
+		return true
+	}
+	for _, entry := range entries {
+		for _, part := range strings.Split(entry, ",") {
+			attr := attributeOf(strings.TrimSpace(part))
+			if attr == path || strings.HasPrefix(path, attr+".") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// isText tells whether an attribute holds text worth searching, rather than
+// URLs, identifiers or numbers.
+func (a *advisor) isText(attr *profile.Attribute) bool {
+	if !attr.Is(profile.TypeString) || a.matches.nbStrings[attr.Path] == 0 {
+		return false
+	}
+	nb := float64(a.matches.nbStrings[attr.Path])
+	if float64(a.matches.nbURLs[attr.Path])/nb >= minCodeShare {
+		return false
+	}
+	name := strings.ToLower(attr.Path[strings.LastIndex(attr.Path, ".")+1:])
+	if (name == "id" || strings.HasSuffix(name, "_id") || strings.HasSuffix(attr.Path, "Id")) && float64(a.matches.nbCodes[attr.Path])/nb >= minCodeShare {
+		return false
+	}
+	return true
+}
+
+func (a *advisor) searchableAttributes() []suggestion {
+	if len(a.settings.SearchableAttributes.Get()) == 0 {
+		return a.explicitSearchableAttributes()
+	}
+
+	// Attributes having the words of no-result searches, which searches
+	// would have found if they were searchable.
+	type found struct {
+		queries  []string
+		searches int
+		records  int
+	}
+	byPath := map[string]*found{}
+	for i, hits := range a.matches.hits {
+		for path, n := range hits {
+			if a.searchable(path) {
+				continue
+			}
+			f, ok := byPath[path]
+			if !ok {
+				f = &found{}
+				byPath[path] = f
+			}
+			f.queries = append(f.queries, a.matches.queries[i].Search)
+			f.searches += a.matches.queries[i].Count
+			f.records += n
+		}
+	}
+	paths := make([]string, 0, len(byPath))
+	for path := range byPath {
+		paths = append(paths, path)
+	}
+	sort.Slice(paths, func(i, j int) bool {
+		if byPath[paths[i]].searches != byPath[paths[j]].searches {
+			return byPath[paths[i]].searches > byPath[paths[j]].searches
+		}
+		return paths[i] < paths[j]
+	})
+
+	suggestions := []suggestion{}
+	for _, path := range paths {
+		f := byPath[path]
+		sort.Strings(f.queries)
+		confidence := "medium"
+		if len(f.queries) >= 3 {
+			confidence = "high"
+		}
+		entry := path
+		if attr := a.profile.Attribute(path); attr != nil && attr.AvgStringLength != nil && *attr.AvgStringLength > longText {
+			entry = "unordered(" + path + ")"
+		}
+		suggestions = append(suggestions, suggestion{
+			ID:       "searchable:" + path,
+			Category: categorySearchable,
+			Title:    fmt.Sprintf("Make %q searchable", path),
+			Rationale: fmt.Sprintf("%d no-result searches (%d searches in total) have all their words in %q in %s, but it isn't searchable. "+
+				"It is added with the lowest priority; move it up if it matters more than the other attributes.",
+				len(f.queries), f.searches, path, a.sampled()),
+			Evidence: map[string]any{
+				"noResultSearches": f.queries,
+				"matchingRecords":  f.records,
+			},
+			Confidence: confidence,
+			edit: func(d *draft) {
+				d.appendTo("searchableAttributes", entry)
+			},
+		})
+	}
+	return suggestions
+}
+
+// explicitSearchableAttributes proposes a list of searchable attributes for
+// an index that searches every attribute.
+func (a *advisor) explicitSearchableAttributes() []suggestion {
+	candidates := []*profile.Attribute{}
+	excluded := []string{}
+	for _, attr := range a.profile.Attributes {
+		if !attr.Is(profile.TypeString) {
+			continue
+		}
+		if !a.isText(attr) {
+			excluded = append(excluded, attr.Path)
+			continue
+		}
+		candidates = append(candidates, attr)
+	}
+	if len(candidates) == 0 {
+		return nil
+	}
+
+	// Short attributes, e.g. names and brands, usually matter more than
+	// long descriptions.
+	rank := func(attr *profile.Attribute) int {
+		name := strings.ToLower(attr.Path)
+		switch {
+		case strings.Contains(name, "title") || strings.Contains(name, "name"):
+			return 0
+		case strings.Contains(name, "description") || strings.Contains(name, "content") || strings.Contains(name, "body"):
+			return 2
+		default:
+			return 1
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		ri, rj := rank(candidates[i]), rank(candidates[j])
+		if ri != rj {
+			return ri < rj
+		}
+		li, lj := 0.0, 0.0
+		if candidates[i].AvgStringLength != nil {
+			li = *candidates[i].AvgStringLength
+		}
+		if candidates[j].AvgStringLength != nil {
+			lj = *candidates[j].AvgStringLength
+		}
+		return li < lj
+	})
+	entries := make([]string, len(candidates))
+	for i, attr := range candidates {
+		entries[i] = attr.Path
+		if attr.AvgStringLength != nil && *attr.AvgStringLength > longText {
+			entries[i] = "unordered(" + attr.Path + ")"
+		}
+	}
+
+	return []suggestion{{
+		ID:       "searchable:explicit",
+		Category: categorySearchable,
+		Title:    "Declare the searchable attributes",
+		Rationale: fmt.Sprintf("searchableAttributes is empty, so every attribute is searchable with the same priority, numbers, URLs and identifiers included. "+
+			"This list keeps the %d text attributes of %s, names and titles first and long text unordered; review the order, as it ranks matches.",
+			len(entries), a.sampled()),
+		Evidence: map[string]any{
+			"excludedAttributes": excluded,
+		},
+		Confidence: "medium",
+		edit: func(d *draft) {
+			d.set("searchableAttributes", entries)
+		},
+	}}
+}
+
+// facetingAttributes declares the attributes searches filter on.
+func (a *advisor) facetingAttributes() []suggestion {
+	declared := map[string]bool{}
+	for _, entry := range a.settings.AttributesForFaceting.Get() {
+		declared[attributeOf(entry)] = true
+	}
+
+	suggestions := []suggestion{}
+	for _, f := range a.usage.Filters {
+		attr := f.Attribute
+		if attr == "" || attr == "_tags" || declared[attr] {
+			continue
+		}
+		// Numeric filters work without faceting.
+		p := a.profile.Attribute(attr)
+		if p != nil && p.Only(profile.TypeInteger, profile.TypeNumber) {
+			continue
+		}
+		rationale := fmt.Sprintf("searches filtered on %q %d times, but it isn't in attributesForFaceting, so these filters match nothing. "+
+			"It is declared filterOnly; use searchable(%s) or the bare attribute instead to also facet on it.", attr, f.Count, attr)
+		confidence := "high"
+		if p == nil {
+			rationale += fmt.Sprintf(" It isn't in any of %s though, check its name.", a.sampled())
+			confidence = "low"
+		}
+		suggestions = append(suggestions, suggestion{
+			ID:         "faceting:" + attr,
+			Category:   categoryFaceting,
+			Title:      fmt.Sprintf("Declare %q for faceting", attr),
+			Rationale:  rationale,
+			Evidence:   map[string]any{"filterCount": f.Count},
+			Confidence: confidence,
+			edit: func(d *draft) {
+				d.appendTo("attributesForFaceting", "filterOnly("+attr+")")
+			},
+		})
+	}
+	return suggestions
+}
+
+// synonyms maps no-result searches to close searches that have results.
+func (a *advisor) synonyms() []suggestion {
+	successful := []searchStat{}
+	for _, s := range a.usage.TopSearches {
+		if s.NbHits > 0 && strings.TrimSpace(s.Search) != "" {
+			successful = append(successful, s)
+		}
+	}
+
+	suggestions := []suggestion{}
+	seen := map[string]bool{}
+	for _, nr := range a.usage.NoResultSearches {
+		q := strings.ToLower(strings.TrimSpace(nr.Search))
+		if len(compact(q)) < 3 || seen[q] {
+			continue
+		}
+		seen[q] = true
+
+		var best *searchStat
+		bestDistance := 0
+		for i, s := range successful {
+			t := strings.ToLower(strings.TrimSpace(s.Search))
+			if t == q {
+				continue
+			}
+			var d int
+			if compact(t) == compact(q) {
+				d = 0
+			} else {
+				d = distance(q, t)
+				if d > maxDistance(q) {
+					continue
+				}
+			}
+			if best == nil || d < bestDistance || (d == bestDistance && s.Count > best.Count) {
+				best, bestDistance = &successful[i], d
+			}
+		}
+		if best == nil {
+			continue
+		}
+
+		t := strings.ToLower(strings.TrimSpace(best.Search))
+		evidence := map[string]any{
+			"noResultSearch":   nr.Search,
+			"noResultCount":    nr.Count,
+			"successfulSearch": best.Search,
+			"successfulCount":  best.Count,
+			"successfulNbHits": best.NbHits,
+		}
+		objectID := "suggested-" + slug(q)
+		var synonym map[string]any
+		var s suggestion
+		if bestDistance == 0 {
+			synonym = map[string]any{"objectID": objectID, "type": "synonym", "synonyms": []string{q, t}}
+			s = suggestion{
+				Title: fmt.Sprintf("Make %q and %q synonyms", q, t),
+				Rationale: fmt.Sprintf("%q got no results %d times, while %q, which only differs by spaces or hyphens, has %d results. "+
+					"The engine only partly handles splitting and concatenating words, a regular synonym makes both spellings match the same records.",
+					q, nr.Count, t, best.NbHits),
+				Confidence: "high",
+			}
+		} else {
+			synonym = map[string]any{"objectID": objectID, "type": "oneWaySynonym", "input": q, "synonyms": []string{t}}
+			s = suggestion{
+				Title: fmt.Sprintf("Add %q as a one-way synonym of %q", t, q),
+				Rationale: fmt.Sprintf("%q got no results %d times, and is at an edit distance of %d from %q, which has %d results. "+
+					"A one-way synonym makes searches for the former also match the latter, without changing searches for the latter. Check it isn't a different product or word.",
+					q, nr.Count, bestDistance, t, best.NbHits),
+				Confidence: "medium",
+			}
+		}
+		b, _ := json.Marshal(synonym)
+		s.ID = "synonym:" + objectID
+		s.Category = categorySynonym
+		s.Evidence = evidence
+		s.Apply = apply{
+			Tool: "save_synonym",
+			Arguments: map[string]any{
+				"indexName": a.indexName,
+				"objectID":  objectID,
+				"synonym":   string(b),
+			},
+		}
+		suggestions = append(suggestions, s)
+	}
+	return suggestions
+}
+
+// compact removes spaces and hyphens.
+func compact(s string) string {
+	return strings.NewReplacer(" ", "", "-", "").Replace(s)
+}
+
+// maxDistance is the edit distance under which two searches are considered
+// variants of one another, following the typo tolerance thresholds.
+func maxDistance(s string) int {
+	switch n := len([]rune(s)); {
+	case n >= 8:
+		return 2
+	case n >= 4:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// distance is the Levenshtein distance between two strings.
+func distance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	cur := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		cur[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(rb)]
+}
+
+// slug turns a search into an objectID.
+func slug(s string) string {
+	return strings.Join(strings.FieldsFunc(s, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}), "-")
+}
+
+// typoTolerance disables typos on codes and numbers, where a close match is
+// a different product rather than a misspelling.
+func (a *advisor) typoTolerance() []suggestion {
+	suggestions := []suggestion{}
+
+	disabled := a.settings.DisableTypoToleranceOnAttributes.Get()
+	for _, attr := range a.profile.Attributes {
+		nb := a.matches.nbStrings[attr.Path]
+		if nb == 0 || !a.searchable(attr.Path) || slices.Contains(disabled, attr.Path) {
+			continue
+		}
+		share := float64(a.matches.nbCodes[attr.Path]) / float64(nb)
+		if share < minCodeShare {
+			continue
+		}
+		path := attr.Path
+		suggestions = append(suggestions, suggestion{
+			ID:       "typo:" + path,
+			Category: categoryTypo,
+			Title:    fmt.Sprintf("Disable typo tolerance on %q", path),
+			Rationale: fmt.Sprintf("%.0f%% of the values of %q in %s look like codes, e.g. SKUs or references, for which a typo matches another item rather than fixing a misspelling.",
+				share*100, path, a.sampled()),
+			Evidence: map[string]any{
+				"codeShare": share,
+				"examples":  examples(attr),
+			},
+			Confidence: "medium",
+			edit: func(d *draft) {
+				d.appendTo("disableTypoToleranceOnAttributes", path)
+			},
+		})
+	}
+
+	if allowed := a.settings.AllowTyposOnNumericTokens; allowed == nil || allowed.Get() {
+		total, numeric := 0, 0
+		queries := []string{}
+		for _, s := range a.usage.TopSearches {
+			total += s.Count
+			if hasNumericToken(s.Search) {
+				numeric += s.Count
+				if len(queries) < 10 {
+					queries = append(queries, s.Search)
+				}
+			}
+		}
+		if total > 0 && float64(numeric)/float64(total) >= minNumericSearchShare {
+			share := float64(numeric) / float64(total)
+			suggestions = append(suggestions, suggestion{
+				ID:       "typo:numericTokens",
+				Category: categoryTypo,
+				Title:    "Disable typos on numbers",
+				Rationale: fmt.Sprintf("%.0f%% of the top searches contain numbers, e.g. models or sizes, which typo tolerance matches to other numbers (a search for 2020 matching 2021).",
+					share*100),
+				Evidence: map[string]any{
+					"numericSearchShare": share,
+					"examples":           queries,
+				},
+				Confidence: "medium",
+				edit: func(d *draft) {
+					d.set("allowTyposOnNumericTokens", false)
+				},
+			})
+		}
+	}
+	return suggestions
+}
+
+func hasNumericToken(s string) bool {
+	for _, w := range tokenize(s) {
+		if strings.IndexFunc(w, unicode.IsDigit) >= 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func examples(attr *profile.Attribute) []string {
+	values := []string{}
+	for _, v := range attr.TopValues {
+		if isCode(v.Value) {
+			values = append(values, v.Value)
+		}
+	}
+	return values
+}
+
+// customRanking proposes popularity attributes for an index without custom
+// ranking.
+func (a *advisor) customRanking() []suggestion {
+	if len(a.settings.CustomRanking.Get()) > 0 {
+		return nil
+	}
+	suggestions := []suggestion{}
+	for _, attr := range a.profile.Attributes {
+		if !attr.Is(profile.TypeInteger, profile.TypeNumber) || !attr.Only(profile.TypeInteger, profile.TypeNumber) || attr.Types[profile.TypeArray] > 0 {
+			continue
+		}
+		name := strings.ToLower(attr.Path)
+		if !slices.ContainsFunc(popularityWords, func(w string) bool { return strings.Contains(name, w) }) {
+			continue
+		}
+		path := attr.Path
+		confidence := "medium"
+		if attr.FillRate < 0.5 {
+			confidence = "low"
+		}
+		suggestions = append(suggestions, suggestion{
+			ID:       "ranking:" + path,
+			Category: categoryRanking,
+			Title:    fmt.Sprintf("Rank by %q", path),
+			Rationale: fmt.Sprintf("customRanking is empty, so records matching a search equally well come in no meaningful order. "+
+				"%q looks like a popularity metric and is set on %.0f%% of %s. When adding several, order them by importance.",
+				path, attr.FillRate*100, a.sampled()),
+			Evidence:   map[string]any{"min": attr.Min, "max": attr.Max, "fillRate": attr.FillRate},
+			Confidence: confidence,
+			edit: func(d *draft) {
+				d.appendTo("customRanking", "desc("+path+")")
+			},
+		})
+	}
+	return suggestions
+}
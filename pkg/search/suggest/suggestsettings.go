@@ -0,0 +1,167 @@
+package suggest
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/algolia/mcp/pkg/analytics"
+	"github.com/algolia/mcp/pkg/mcputil"
+	"github.com/algolia/mcp/pkg/search/indexcache"
+	"github.com/algolia/mcp/pkg/search/profile"
+)
+
+const (
+	// defaultDays is the default analytics period.
+	defaultDays = 30
+	// defaultNbSearches is the default number of top and no-result searches
+	// analyzed.
+	defaultNbSearches = 100
+	// maxNbSearches is the analytics API limit.
+	maxNbSearches = 1000
+)
+
+// searchStat is a search from analytics.
+type searchStat struct {
+	Search string `json:"search"`
+	Count  int    `json:"count"`
+	NbHits int    `json:"nbHits"`
+}
+
+// filterAttribute is an attribute searches filter on, from analytics.
+type filterAttribute struct {
+	Attribute string `json:"attribute"`
+	Count     int    `json:"count"`
+}
+
+// usage is what analytics tell about the searches on an index.
+type usage struct {
+	TopSearches      []searchStat
+	NoResultSearches []searchStat
+	Filters          []filterAttribute
+}
+
+func RegisterSuggestSettings(mcps *server.MCPServer, cache *indexcache.Cache) {
+	suggestSettingsTool := mcp.NewTool(
+		"suggest_settings",
+		mcp.WithDescription("Recommend setting changes for an index from its record profile, current settings, top searches, no-result searches and filters. "+
+			"Suggests searchable attributes missing for no-result queries, attributes filtered on but not declared for faceting, synonyms for no-result queries close to successful ones, "+
+			"typo tolerance exceptions for codes and numbers, custom ranking and fixes for settings referring to missing attributes. "+
+			"Each suggestion has a rationale and a ready-to-apply patch_settings or save_synonym call; nothing is changed"),
+		mcp.WithString(
+			"indexName",
+			mcp.Description(indexcache.IndexNameDescription),
+		),
+		mcp.WithNumber(
+			"days",
+			mcp.Description(fmt.Sprintf("The number of days of analytics to analyze (default: %d)", defaultDays)),
+		),
+		mcp.WithNumber(
+			"nbSearches",
+			mcp.Description(fmt.Sprintf("The number of top and no-result searches to analyze (default: %d, max: %d)", defaultNbSearches, maxNbSearches)),
+		),
+		mcp.WithNumber(
+			"sampleSize",
+			mcp.Description(fmt.Sprintf("The number of records to profile (default: %d)", profile.DefaultSampleSize)),
+		),
+	)
+
+	mcps.AddTool(suggestSettingsTool, func(_ context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		index, err := cache.FromRequest(req)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		days := defaultDays
+		if n, ok := req.Params.Arguments["days"].(float64); ok && n >= 1 {
+			days = int(n)
+		}
+		nbSearches := defaultNbSearches
+		if n, ok := req.Params.Arguments["nbSearches"].(float64); ok && n >= 1 {
+			nbSearches = min(int(n), maxNbSearches)
+		}
+		sampleSize := profile.DefaultSampleSize
+		if n, ok := req.Params.Arguments["sampleSize"].(float64); ok && n >= 1 {
+			sampleSize = int(n)
+		}
+
+		settings, err := index.GetSettings()
+		if err != nil {
+			return nil, fmt.Errorf("could not get settings: %w", err)
+		}
+
+		// Analytics may be unavailable, e.g. without the analytics ACL, in
+		// which case suggestions only rely on the records.
+		warnings := []string{}
+		u, err := fetchUsage(cache.AppID(), cache.APIKey(), index.GetName(), days, nbSearches)
+		if err != nil {
+			warnings = append(warnings, fmt.Sprintf("analytics are unavailable, suggestions only rely on the records and settings: %v", err))
+		}
+
+		matches := newQueryMatcher(u.NoResultSearches)
+		p, err := profile.Build(index, sampleSize, matches.visit)
+		if err != nil {
+			return nil, err
+		}
+
+		a := &advisor{
+			indexName: index.GetName(),
+			settings:  settings,
+			profile:   p,
+			usage:     u,
+			matches:   matches,
+		}
+		suggestions := a.suggest()
+
+		return mcputil.JSONToolResult("settings suggestions", map[string]any{
+			"indexName":          index.GetName(),
+			"analyticsDays":      days,
+			"nbRecordsProfiled":  p.NbProfiled,
+			"nbRecords":          p.NbRecords,
+			"nbTopSearches":      len(u.TopSearches),
+			"nbNoResultSearches": len(u.NoResultSearches),
+			"warnings":           warnings,
+			"suggestions":        suggestions,
+			"combinedPatch":      a.combinedPatch(suggestions),
+		})
+	})
+}
+
+// fetchUsage reads the top searches, no-result searches and filtered
+// attributes of the last days.
+func fetchUsage(appID, apiKey, indexName string, days, limit int) (usage, error) {
+	params := url.Values{}
+	params.Set("index", indexName)
+	params.Set("startDate", time.Now().AddDate(0, 0, -days).Format(time.DateOnly))
+	params.Set("endDate", time.Now().Format(time.DateOnly))
+	params.Set("limit", strconv.Itoa(limit))
+
+	var u usage
+	var searches struct {
+		Searches []searchStat `json:"searches"`
+	}
+	if err := analytics.Get(appID, apiKey, "/2/searches", params, &searches); err != nil {
+		return u, fmt.Errorf("could not get top searches: %w", err)
+	}
+	u.TopSearches = searches.Searches
+
+	searches.Searches = nil
+	if err := analytics.Get(appID, apiKey, "/2/searches/noResults", params, &searches); err != nil {
+		return u, fmt.Errorf("could not get no-result searches: %w", err)
+	}
+	u.NoResultSearches = searches.Searches
+
+	var filters struct {
+		Attributes []filterAttribute `json:"attributes"`
+	}
+	if err := analytics.Get(appID, apiKey, "/2/filters", params, &filters); err != nil {
+		return u, fmt.Errorf("could not get filters: %w", err)
+	}
+	u.Filters = filters.Attributes
+	return u, nil
+}
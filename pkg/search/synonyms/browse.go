@@ -0,0 +1,31 @@
+package synonyms
+
+import (
+	"fmt"
+
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/opt"
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/search"
+)
+
+// pageSize is the number of synonyms fetched per page, the API maximum.
+const pageSize = 1000
+
+// Browse fetches every synonym of the index. The client's iterator stops at
+// the first page, so synonyms are paged here.
+func Browse(index *search.Index) ([]search.Synonym, error) {
+	synonyms := []search.Synonym{}
+	for page := 0; ; page++ {
+		res, err := index.SearchSynonyms("", opt.Page(page), opt.HitsPerPage(pageSize))
+		if err != nil {
+			return nil, fmt.Errorf("could not search synonyms: %w", err)
+		}
+		pageSynonyms, err := res.Synonyms()
+		if err != nil {
+			return nil, fmt.Errorf("could not decode synonyms: %w", err)
+		}
+		synonyms = append(synonyms, pageSynonyms...)
+		if len(pageSynonyms) < pageSize || len(synonyms) >= res.NbHits {
+			return synonyms, nil
+		}
+	}
+}
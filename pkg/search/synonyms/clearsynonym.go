@@ -4,24 +4,39 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/algolia/algoliasearch-client-go/v3/algolia/search"
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/opt"
 	"github.com/algolia/mcp/pkg/mcputil"
+	"github.com/algolia/mcp/pkg/search/indexcache"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
 )
 
-func RegisterClearSynonyms(mcps *server.MCPServer, writeIndex *search.Index) {
+func RegisterClearSynonyms(mcps *server.MCPServer, cache *indexcache.Cache) {
 	clearSynonymsTool := mcp.NewTool(
 		"clear_synonyms",
 		mcp.WithDescription("Clear all synonyms from the Algolia index"),
+		mcp.WithString(
+			"indexName",
+			mcp.Description(indexcache.IndexNameDescription),
+		),
+		mcp.WithBoolean(
+			"forwardToReplicas",
+			mcp.Description("Whether to also apply the change to the replicas of the index"),
+		),
 	)
 
-	mcps.AddTool(clearSynonymsTool, func(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		if writeIndex == nil {
-			return mcp.NewToolResultError("write API key not set, cannot clear synonyms"), nil
+	mcps.AddTool(clearSynonymsTool, func(_ context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		index, err := cache.FromRequest(req)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		opts := []any{}
+		if forward, ok := req.Params.Arguments["forwardToReplicas"].(bool); ok {
+			opts = append(opts, opt.ForwardToReplicas(forward))
 		}
 
-		res, err := writeIndex.ClearSynonyms()
+		res, err := index.ClearSynonyms(opts...)
 		if err != nil {
 			return nil, fmt.Errorf("could not clear synonyms: %w", err)
 		}
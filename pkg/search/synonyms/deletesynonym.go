@@ -7,28 +7,47 @@ import (
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
 
-	"github.com/algolia/algoliasearch-client-go/v3/algolia/search"
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/opt"
 	"github.com/algolia/mcp/pkg/mcputil"
+	"github.com/algolia/mcp/pkg/search/indexcache"
 )
 
-func RegisterDeleteSynonym(mcps *server.MCPServer, index *search.Index) {
+func RegisterDeleteSynonym(mcps *server.MCPServer, cache *indexcache.Cache) {
 	DeleteSynonymTool := mcp.NewTool(
 		"delete_synonym",
 		mcp.WithDescription("Delete a synonym by its object ID"),
+		mcp.WithString(
+			"indexName",
+			mcp.Description(indexcache.IndexNameDescription),
+		),
 		mcp.WithString(
 			"objectID",
 			mcp.Description("The object ID to delete"),
 			mcp.Required(),
 		),
+		mcp.WithBoolean(
+			"forwardToReplicas",
+			mcp.Description("Whether to also apply the change to the replicas of the index"),
+		),
 	)
 
 	mcps.AddTool(DeleteSynonymTool, func(_ context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		index, err := cache.FromRequest(req)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
 		objectID, ok := req.Params.Arguments["objectID"].(string)
 		if !ok {
 			return mcp.NewToolResultError("invalid object format, expected JSON string"), nil
 		}
 
-		resp, err := index.DeleteSynonym(objectID)
+		opts := []any{}
+		if forward, ok := req.Params.Arguments["forwardToReplicas"].(bool); ok {
+			opts = append(opts, opt.ForwardToReplicas(forward))
+		}
+
+		resp, err := index.DeleteSynonym(objectID, opts...)
 		if err != nil {
 			return nil, fmt.Errorf("could not delete synonyms: %w", err)
 		}
@@ -4,16 +4,20 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/algolia/algoliasearch-client-go/v3/algolia/search"
 	"github.com/algolia/mcp/pkg/mcputil"
+	"github.com/algolia/mcp/pkg/search/indexcache"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
 )
 
-func RegisterGetSynonym(mcps *server.MCPServer, index *search.Index) {
+func RegisterGetSynonym(mcps *server.MCPServer, cache *indexcache.Cache) {
 	getSynonymTool := mcp.NewTool(
 		"get_synonym",
 		mcp.WithDescription("Get a synonym from the Algolia index by its ID"),
+		mcp.WithString(
+			"indexName",
+			mcp.Description(indexcache.IndexNameDescription),
+		),
 		mcp.WithString(
 			"objectID",
 			mcp.Description("The unique identifier of the synonym to retrieve"),
@@ -22,6 +26,11 @@ func RegisterGetSynonym(mcps *server.MCPServer, index *search.Index) {
 	)
 
 	mcps.AddTool(getSynonymTool, func(_ context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		index, err := cache.FromRequest(req)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
 		objectID, ok := req.Params.Arguments["objectID"].(string)
 		if !ok {
 			return mcp.NewToolResultError("invalid objectID format"), nil
@@ -10,18 +10,22 @@ import (
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
 
-	"github.com/algolia/algoliasearch-client-go/v3/algolia/search"
 	"github.com/algolia/mcp/pkg/mcputil"
+	"github.com/algolia/mcp/pkg/search/indexcache"
 )
 
 const (
 	synonymsBaseURL = "https://%s.algolia.net/1/indexes/%s/synonyms/%s"
 )
 
-func RegisterInsertSynonym(mcps *server.MCPServer, index *search.Index, appID, apiKey string) {
+func RegisterInsertSynonym(mcps *server.MCPServer, cache *indexcache.Cache) {
 	insertSynonymTool := mcp.NewTool(
 		"save_synonym",
 		mcp.WithDescription("Save or update a synonym in the Algolia index"),
+		mcp.WithString(
+			"indexName",
+			mcp.Description(indexcache.IndexNameDescription),
+		),
 		mcp.WithString(
 			"objectID",
 			mcp.Description("The unique identifier of the synonym"),
@@ -32,9 +36,19 @@ func RegisterInsertSynonym(mcps *server.MCPServer, index *search.Index, appID, a
 			mcp.Description("The synonym object as a JSON string. Example schema: {\"objectID\":\"unique_id\",\"type\":\"synonym\",\"synonyms\":[\"word1\",\"word2\",\"word3\"]} or {\"objectID\":\"unique_id\",\"type\":\"oneWaySynonym\",\"input\":\"word1\",\"synonyms\":[\"word2\",\"word3\"]} or {\"objectID\":\"unique_id\",\"type\":\"altCorrection1\",\"word\":\"word1\",\"corrections\":[\"word2\",\"word3\"]} or {\"objectID\":\"unique_id\",\"type\":\"altCorrection2\",\"word\":\"word1\",\"corrections\":[\"word2\",\"word3\"]} or {\"objectID\":\"unique_id\",\"type\":\"placeholder\",\"placeholder\":\"<em>`,\"replacements\":[\"word1\",\"word2\"]}"),
 			mcp.Required(),
 		),
+		mcp.WithBoolean(
+			"forwardToReplicas",
+			mcp.Description("Whether to also apply the change to the replicas of the index"),
+		),
 	)
 
 	mcps.AddTool(insertSynonymTool, func(_ context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		index, err := cache.FromRequest(req)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		appID, apiKey := cache.AppID(), cache.APIKey()
 		indexName := index.GetName()
 		objectID, ok := req.Params.Arguments["objectID"].(string)
 		if !ok {
@@ -60,6 +74,9 @@ func RegisterInsertSynonym(mcps *server.MCPServer, index *search.Index, appID, a
 
 		// Build request URL
 		url := fmt.Sprintf(synonymsBaseURL, appID, indexName, objectID)
+		if forward, ok := req.Params.Arguments["forwardToReplicas"].(bool); ok && forward {
+			url += "?forwardToReplicas=true"
+		}
 
 		// Create request
 		httpReq, err := http.NewRequest(http.MethodPut, url, bytes.NewBuffer([]byte(synonymStr)))
@@ -7,14 +7,18 @@ import (
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
 
-	"github.com/algolia/algoliasearch-client-go/v3/algolia/search"
 	"github.com/algolia/mcp/pkg/mcputil"
+	"github.com/algolia/mcp/pkg/search/indexcache"
 )
 
-func RegisterSearchSynonym(mcps *server.MCPServer, index *search.Index) {
+func RegisterSearchSynonym(mcps *server.MCPServer, cache *indexcache.Cache) {
 	searchSynonymTool := mcp.NewTool(
 		"search_synonyms",
 		mcp.WithDescription("Search for synonyms in the Algolia index that match a query"),
+		mcp.WithString(
+			"indexName",
+			mcp.Description(indexcache.IndexNameDescription),
+		),
 		mcp.WithString(
 			"query",
 			mcp.Description("The query to find synonyms for"),
@@ -22,6 +26,11 @@ func RegisterSearchSynonym(mcps *server.MCPServer, index *search.Index) {
 	)
 
 	mcps.AddTool(searchSynonymTool, func(_ context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		index, err := cache.FromRequest(req)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
 		query, _ := req.Params.Arguments["query"].(string)
 
 		resp, err := index.SearchSynonyms(query)
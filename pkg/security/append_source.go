@@ -0,0 +1,52 @@
+package security
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/call"
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/search"
+	"github.com/algolia/mcp/pkg/mcputil"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// RegisterAppendSource registers the append_source tool with the MCP server.
+func RegisterAppendSource(mcps *server.MCPServer, client *search.Client) {
+	appendSourceTool := mcp.NewTool(
+		"security_append_source",
+		mcp.WithDescription("Add an IP address range to the sources allowed to access the application"),
+		mcp.WithString(
+			"source",
+			mcp.Description("The IP address or CIDR range to allow (e.g. 10.0.0.1/32)"),
+			mcp.Required(),
+		),
+		mcp.WithString(
+			"description",
+			mcp.Description("Description of the source (e.g. Paris office)"),
+		),
+	)
+
+	mcps.AddTool(appendSourceTool, func(_ context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		source, _ := req.Params.Arguments["source"].(string)
+		if source == "" {
+			return nil, fmt.Errorf("source parameter is required")
+		}
+		if err := validateSource(source); err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		description, _ := req.Params.Arguments["description"].(string)
+
+		var res map[string]any
+		body := Source{Source: source, Description: description}
+		if err := client.CustomRequest(&res, http.MethodPost, sourcesPath+"/append", body, call.Write); err != nil {
+			return nil, fmt.Errorf("could not append source: %w", err)
+		}
+
+		return mcputil.JSONToolResult("Append Result", map[string]any{
+			"source":    body,
+			"createdAt": res["createdAt"],
+		})
+	})
+}
@@ -0,0 +1,72 @@
+package security
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/call"
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/search"
+	"github.com/algolia/mcp/pkg/mcputil"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// RegisterDeleteSource registers the delete_source tool with the MCP server.
+func RegisterDeleteSource(mcps *server.MCPServer, client *search.Client) {
+	deleteSourceTool := mcp.NewTool(
+		"security_delete_source",
+		mcp.WithDescription("Remove an IP address range from the sources allowed to access the application. Requests from it are rejected immediately. Without confirm, only returns the source that would be removed. Removing the range you connect from locks you out"),
+		mcp.WithString(
+			"source",
+			mcp.Description("The IP address or CIDR range to remove, as listed by security_get_sources"),
+			mcp.Required(),
+		),
+		mcp.WithBoolean(
+			"confirm",
+			mcp.Description("Set to true to actually remove the source after reviewing it"),
+		),
+	)
+
+	mcps.AddTool(deleteSourceTool, func(_ context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		source, _ := req.Params.Arguments["source"].(string)
+		if source == "" {
+			return nil, fmt.Errorf("source parameter is required")
+		}
+
+		current, err := getSources(client)
+		if err != nil {
+			return nil, err
+		}
+		var removed *Source
+		for i := range current {
+			if current[i].Source == source {
+				removed = &current[i]
+			}
+		}
+		if removed == nil {
+			return mcp.NewToolResultError(fmt.Sprintf("source %q is not in the list of allowed sources", source)), nil
+		}
+
+		result := map[string]any{"removed": removed}
+		warnings := []string{fmt.Sprintf("%s will lose access immediately", source)}
+		if len(current) == 1 {
+			warnings = append(warnings, "the list will be empty: every source will be allowed")
+		}
+		result["warnings"] = warnings
+
+		if confirm, _ := req.Params.Arguments["confirm"].(bool); !confirm {
+			result["applied"] = false
+			return mcputil.JSONToolResult("Delete Source Preview", result)
+		}
+
+		var res map[string]any
+		if err := client.CustomRequest(&res, http.MethodDelete, sourcePath(source), nil, call.Write); err != nil {
+			return nil, fmt.Errorf("could not delete source: %w", err)
+		}
+		result["applied"] = true
+		result["deletedAt"] = res["deletedAt"]
+
+		return mcputil.JSONToolResult("Delete Result", result)
+	})
+}
@@ -0,0 +1,27 @@
+package security
+
+import (
+	"context"
+
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/search"
+	"github.com/algolia/mcp/pkg/mcputil"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// RegisterGetSources registers the get_sources tool with the MCP server.
+func RegisterGetSources(mcps *server.MCPServer, client *search.Client) {
+	getSourcesTool := mcp.NewTool(
+		"security_get_sources",
+		mcp.WithDescription("List the IP address ranges allowed to access the application. An empty list means every source is allowed"),
+	)
+
+	mcps.AddTool(getSourcesTool, func(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		sources, err := getSources(client)
+		if err != nil {
+			return nil, err
+		}
+
+		return mcputil.JSONToolResult("Sources", sources)
+	})
+}
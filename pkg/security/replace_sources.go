@@ -0,0 +1,123 @@
+package security
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/call"
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/search"
+	"github.com/algolia/mcp/pkg/mcputil"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// sourcesDiff is the difference between the current and the proposed sources.
+type sourcesDiff struct {
+	Added       []Source `json:"added"`
+	Removed     []Source `json:"removed"`
+	Changed     []Source `json:"changed"`
+	NbUnchanged int      `json:"nbUnchanged"`
+}
+
+func diffSources(current, proposed []Source) sourcesDiff {
+	diff := sourcesDiff{Added: []Source{}, Removed: []Source{}, Changed: []Source{}}
+
+	byRange := make(map[string]Source, len(current))
+	for _, s := range current {
+		byRange[s.Source] = s
+	}
+	kept := make(map[string]bool, len(proposed))
+	for _, s := range proposed {
+		kept[s.Source] = true
+		old, ok := byRange[s.Source]
+		switch {
+		case !ok:
+			diff.Added = append(diff.Added, s)
+		case old.Description != s.Description:
+			diff.Changed = append(diff.Changed, s)
+		default:
+			diff.NbUnchanged++
+		}
+	}
+	for _, s := range current {
+		if !kept[s.Source] {
+			diff.Removed = append(diff.Removed, s)
+		}
+	}
+	return diff
+}
+
+// RegisterReplaceSources registers the replace_sources tool with the MCP server.
+func RegisterReplaceSources(mcps *server.MCPServer, client *search.Client) {
+	replaceSourcesTool := mcp.NewTool(
+		"security_replace_sources",
+		mcp.WithDescription("Replace the whole list of sources allowed to access the application. Without confirm, only returns the difference with the current list. Removing the range you connect from locks you out"),
+		mcp.WithString(
+			"sources",
+			mcp.Description(`JSON array of sources, e.g. [{"source": "10.0.0.1/32", "description": "Paris office"}]. An empty array allows every source`),
+			mcp.Required(),
+		),
+		mcp.WithBoolean(
+			"confirm",
+			mcp.Description("Set to true to actually replace the sources after reviewing the difference"),
+		),
+	)
+
+	mcps.AddTool(replaceSourcesTool, func(_ context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		sourcesJSON, _ := req.Params.Arguments["sources"].(string)
+		if sourcesJSON == "" {
+			return nil, fmt.Errorf("sources parameter is required")
+		}
+		var proposed []Source
+		if err := json.Unmarshal([]byte(sourcesJSON), &proposed); err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("invalid sources JSON: %v", err)), nil
+		}
+		seen := make(map[string]bool, len(proposed))
+		for _, s := range proposed {
+			if err := validateSource(s.Source); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if seen[s.Source] {
+				return mcp.NewToolResultError(fmt.Sprintf("duplicate source %q", s.Source)), nil
+			}
+			seen[s.Source] = true
+		}
+		if proposed == nil {
+			proposed = []Source{}
+		}
+
+		current, err := getSources(client)
+		if err != nil {
+			return nil, err
+		}
+		diff := diffSources(current, proposed)
+
+		result := map[string]any{"diff": diff}
+		var warnings []string
+		if len(proposed) == 0 && len(current) > 0 {
+			warnings = append(warnings, "the new list is empty: every source will be allowed")
+		}
+		if len(diff.Removed) > 0 {
+			warnings = append(warnings, fmt.Sprintf("%d sources will lose access immediately", len(diff.Removed)))
+		}
+		if len(warnings) > 0 {
+			result["warnings"] = warnings
+		}
+
+		if confirm, _ := req.Params.Arguments["confirm"].(bool); !confirm {
+			result["applied"] = false
+			return mcputil.JSONToolResult("Replace Sources Preview", result)
+		}
+
+		var res map[string]any
+		if err := client.CustomRequest(&res, http.MethodPut, sourcesPath, proposed, call.Write); err != nil {
+			return nil, fmt.Errorf("could not replace sources: %w", err)
+		}
+		result["applied"] = true
+		result["updatedAt"] = res["updatedAt"]
+
+		return mcputil.JSONToolResult("Replace Sources Result", result)
+	})
+}
@@ -0,0 +1,56 @@
+// Package security provides tools for the sources (IP address ranges) allowed
+// to access an Algolia application.
+package security
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/call"
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/search"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// RegisterAll registers all security tools with the MCP server. The client
+// must be created with an admin API key.
+func RegisterAll(mcps *server.MCPServer, client *search.Client) {
+	// Register all security tools.
+	RegisterGetSources(mcps, client)
+	RegisterReplaceSources(mcps, client)
+	RegisterAppendSource(mcps, client)
+	RegisterDeleteSource(mcps, client)
+}
+
+const sourcesPath = "/1/security/sources"
+
+// Source is an IP address range allowed to access the application.
+type Source struct {
+	Source      string `json:"source"`
+	Description string `json:"description,omitempty"`
+}
+
+// getSources returns the sources allowed to access the application.
+func getSources(client *search.Client) ([]Source, error) {
+	sources := []Source{}
+	if err := client.CustomRequest(&sources, http.MethodGet, sourcesPath, nil, call.Read); err != nil {
+		return nil, fmt.Errorf("could not get sources: %w", err)
+	}
+	return sources, nil
+}
+
+// validateSource checks that s is an IP address or a CIDR range.
+func validateSource(s string) error {
+	if net.ParseIP(s) != nil {
+		return nil
+	}
+	if _, _, err := net.ParseCIDR(s); err == nil {
+		return nil
+	}
+	return fmt.Errorf("invalid source %q: expected an IP address or a CIDR range (e.g. 10.0.0.1/32)", s)
+}
+
+func sourcePath(source string) string {
+	return sourcesPath + "/" + url.PathEscape(source)
+}